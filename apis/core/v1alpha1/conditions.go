@@ -0,0 +1,168 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Condition types.
+const (
+	// TypeValidated indicates whether the Data Flow server accepted all apps
+	// referenced by the definition.
+	TypeValidated xpv1.ConditionType = "Validated"
+)
+
+// Condition reasons.
+const (
+	ReasonValid    xpv1.ConditionReason = "Valid"
+	ReasonInvalid  xpv1.ConditionReason = "Invalid"
+	ReasonRunning  xpv1.ConditionReason = "Running"
+	ReasonFailed   xpv1.ConditionReason = "Failed"
+	ReasonDegraded xpv1.ConditionReason = "Degraded"
+
+	ReasonInvalidPlatform       xpv1.ConditionReason = "InvalidPlatform"
+	ReasonInvalidCronExpression xpv1.ConditionReason = "InvalidCronExpression"
+	ReasonInvalidTimeZone       xpv1.ConditionReason = "InvalidTimeZone"
+	ReasonSuspended             xpv1.ConditionReason = "Suspended"
+	ReasonWaitingForExecutions  xpv1.ConditionReason = "WaitingForExecutions"
+	ReasonNotRestorable         xpv1.ConditionReason = "NotRestorable"
+)
+
+// Valid returns a condition that indicates the definition was validated
+// successfully by the Data Flow server.
+func Valid() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeValidated,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonValid,
+	}
+}
+
+// Invalid returns a condition that indicates the Data Flow server rejected
+// at least one app referenced by the definition.
+func Invalid() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeValidated,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInvalid,
+	}
+}
+
+// InvalidPlatform returns a condition that indicates the platform is not
+// known to the Data Flow server.
+func InvalidPlatform() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeValidated,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInvalidPlatform,
+	}
+}
+
+// InvalidCronExpression returns a condition that indicates the cron
+// expression is invalid for the platform.
+func InvalidCronExpression() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeValidated,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInvalidCronExpression,
+	}
+}
+
+// InvalidTimeZone returns a condition that indicates the time zone is
+// unknown or not supported by the platform.
+func InvalidTimeZone() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeValidated,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInvalidTimeZone,
+	}
+}
+
+// Running returns a condition that indicates the task execution has not
+// completed yet.
+func Running() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRunning,
+	}
+}
+
+// Failed returns a condition that indicates the task execution completed
+// with an error.
+func Failed() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonFailed,
+	}
+}
+
+// Degraded returns a condition that indicates the external resource was
+// removed by a failed update and could not be restored.
+func Degraded() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDegraded,
+	}
+}
+
+// Suspended returns a condition that indicates the external resource was
+// removed on purpose, while the managed resource is kept.
+func Suspended() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSuspended,
+	}
+}
+
+// WaitingForExecutions returns a condition that indicates the external
+// resource is only updated, once its running executions completed.
+func WaitingForExecutions() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWaitingForExecutions,
+	}
+}
+
+// NotRestorable returns a condition that indicates the external resource is
+// not updated, as it could not be restored if recreating it failed.
+func NotRestorable() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNotRestorable,
+	}
+}
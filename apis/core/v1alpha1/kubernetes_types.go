@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// KubernetesDeployer configures how the Kubernetes deployer runs an app.
+// It is rendered to deployer.<app>.kubernetes.* properties.
+type KubernetesDeployer struct {
+	// Resource limits of the app container
+	// +optional
+	Limits *KubernetesResources `json:"limits,omitempty"`
+
+	// Resource requests of the app container
+	// +optional
+	Requests *KubernetesResources `json:"requests,omitempty"`
+
+	// Environment variables of the app container. Values containing a comma
+	// must not contain a single quote.
+	// +kubebuilder:validation:XValidation:rule="self.all(k, !(self[k].contains(',') && self[k].contains(\"'\")))",message="Values containing a comma must not contain a single quote"
+	// +optional
+	EnvironmentVariables map[string]string `json:"environmentVariables,omitempty"`
+
+	// Names of Secrets, whose keys are exposed as environment variables
+	// +optional
+	SecretRefs []string `json:"secretRefs,omitempty"`
+
+	// Names of ConfigMaps, whose keys are exposed as environment variables
+	// +optional
+	ConfigMapRefs []string `json:"configMapRefs,omitempty"`
+
+	// Annotations of the pod
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// Labels of the deployment and its pods
+	// +optional
+	DeploymentLabels map[string]string `json:"deploymentLabels,omitempty"`
+
+	// Node labels, the pod must be scheduled to
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations of the pod
+	// +optional
+	Tolerations []KubernetesToleration `json:"tolerations,omitempty"`
+
+	// Name of the service account the pod runs with
+	// +optional
+	ServiceAccountName *string `json:"serviceAccountName,omitempty"`
+
+	// Image pull policy of the app container
+	// +optional
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	ImagePullPolicy *string `json:"imagePullPolicy,omitempty"`
+
+	// Name of the Secret used to pull the image
+	// +optional
+	ImagePullSecret *string `json:"imagePullSecret,omitempty"`
+}
+
+// KubernetesResources are the cpu and memory of a container
+type KubernetesResources struct {
+	// Cpu as Kubernetes quantity, i.e. 500m
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$`
+	CPU *string `json:"cpu,omitempty"`
+
+	// Memory as Kubernetes quantity, i.e. 512Mi
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$`
+	Memory *string `json:"memory,omitempty"`
+}
+
+// KubernetesToleration is a toleration of the pod
+type KubernetesToleration struct {
+	// Taint key the toleration applies to
+	// +optional
+	Key *string `json:"key,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Enum=Exists;Equal
+	Operator *string `json:"operator,omitempty"`
+
+	// Taint value the toleration matches
+	// +optional
+	Value *string `json:"value,omitempty"`
+
+	// Taint effect the toleration matches
+	// +optional
+	// +kubebuilder:validation:Enum=NoSchedule;PreferNoSchedule;NoExecute
+	Effect *string `json:"effect,omitempty"`
+
+	// Seconds the pod tolerates a NoExecute taint
+	// +optional
+	TolerationSeconds *int64 `json:"tolerationSeconds,omitempty"`
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// PropertySourceLabel marks the Secrets and ConfigMaps, that are referenced
+// by properties. Only changes of labeled objects are watched, changes of
+// others are detected with the next poll.
+const PropertySourceLabel = "springclouddataflow.crossplane.io/property-source"
+
+// Property is a key value pair, whose value is either set directly or read
+// from a Secret or ConfigMap when the resource is reconciled
+// +kubebuilder:validation:XValidation:rule="has(self.value) != has(self.valueFrom)",message="Exactly one of value or valueFrom is required"
+type Property struct {
+	// Key of the property, i.e. app.log.spring.datasource.password
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+
+	// Value of the property
+	// +optional
+	Value *string `json:"value,omitempty"`
+
+	// Source of the value of the property. The value is never written to the status.
+	// +optional
+	ValueFrom *PropertyValueSource `json:"valueFrom,omitempty"`
+}
+
+// PropertyValueSource references the value of a property
+// +kubebuilder:validation:XValidation:rule="has(self.secretKeyRef) != has(self.configMapKeyRef)",message="Exactly one of secretKeyRef or configMapKeyRef is required"
+type PropertyValueSource struct {
+	// Selects a key of a Secret
+	// +optional
+	SecretKeyRef *xpv1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// Selects a key of a ConfigMap
+	// +optional
+	ConfigMapKeyRef *ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// ConfigMapKeySelector selects a key of a ConfigMap
+type ConfigMapKeySelector struct {
+	// Name of the ConfigMap
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+
+	// Key within the ConfigMap
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+}
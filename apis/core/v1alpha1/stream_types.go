@@ -26,6 +26,7 @@ import (
 )
 
 // StreamParameters are the configurable fields of a Stream.
+// +kubebuilder:validation:XValidation:rule="has(self.definition) != has(self.topology)",message="Exactly one of definition or topology is required"
 type StreamParameters struct {
 	// Name of the stream (immutable)
 	// +kubebuilder:validation:Required
@@ -38,14 +39,129 @@ type StreamParameters struct {
 	Description string `json:"description"`
 
 	// The definition for the stream, using Data Flow DSL (immutable)
-	// +kubebuilder:validation:Required
+	// Exactly one of definition or topology is required.
+	// +optional
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Definition is immutable"
-	Definition string `json:"definition"`
+	Definition string `json:"definition,omitempty"`
+
+	// Structured definition for the stream, that is rendered to Data Flow DSL (immutable)
+	// Exactly one of definition or topology is required.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Topology is immutable"
+	// +kubebuilder:validation:XValidation:rule="!(has(self.sourceDestination) && has(self.tap))",message="Only one of sourceDestination or tap is allowed"
+	// +kubebuilder:validation:XValidation:rule="(has(self.apps) && size(self.apps) > 0) || ((has(self.sourceDestination) || has(self.tap)) && has(self.sinkDestination))",message="Either apps or a source and a sinkDestination are required"
+	Topology *StreamTopology `json:"topology,omitempty"`
+
+	// Name of the stream, that is tapped by this stream (immutable)
+	// The stream is created after the tapped stream exists and the tapped stream can not
+	// be deleted as long as this stream exists. Taps within the definition on other
+	// Streams are detected without it.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="TappedStreamName is immutable"
+	// +crossplane:generate:reference:type=github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1.Stream
+	TappedStreamName *string `json:"tappedStreamName,omitempty"`
+
+	// Stream reference to retrieve the name of the tapped Stream
+	// +optional
+	TappedStreamNameRef *xpv1.Reference `json:"tappedStreamNameRef,omitempty"`
+
+	// TappedStreamNameSelector selects a reference to a Stream and retrieves its name
+	// +optional
+	TappedStreamNameSelector *xpv1.Selector `json:"tappedStreamNameSelector,omitempty"`
 
 	// If true, the stream is deployed upon creation (immutable)
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Deploy is immutable"
 	Deploy bool `json:"deploy"`
+
+	// Properties used when the stream is deployed, i.e. app.<app>.<property> or
+	// deployer.<app>.<property>. Values can be read from Secrets or ConfigMaps, so that
+	// they are neither part of the definition nor of the status. Changes, including
+	// changes of referenced Secrets and ConfigMaps, update the deployed stream.
+	// +optional
+	DeploymentProperties []Property `json:"deploymentProperties,omitempty"`
+
+	// Kubernetes deployer settings keyed by app label or name, rendered to
+	// deployer.<app>.kubernetes.* deployment properties. Properties with the same
+	// key in deploymentProperties take precedence. Changes update the deployed stream.
+	// +optional
+	KubernetesDeployer map[string]KubernetesDeployer `json:"kubernetesDeployer,omitempty"`
+
+	// Skipper platform the stream is deployed to. It must be one of the platforms
+	// reported by Skipper. Changing the platform redeploys the stream.
+	// +optional
+	// +kubebuilder:default=default
+	Platform string `json:"platform,omitempty"`
+
+	// If true, the deployed stream is updated by Skipper, whenever the default
+	// version of one of its apps changes
+	// +optional
+	FollowDefaultVersions bool `json:"followDefaultVersions,omitempty"`
+
+	// Runtime settings keyed by app label or name, that are applied to every running
+	// instance of the app through its actuator endpoints, including instances added by
+	// scaling. Settings are applied once per instance and not reverted, when removed.
+	// +optional
+	Actuator map[string]StreamAppActuator `json:"actuator,omitempty"`
+}
+
+// StreamAppActuator are runtime settings of an app applied through its actuator
+type StreamAppActuator struct {
+	// Logger levels keyed by logger name, i.e. org.springframework.integration: DEBUG
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self.all(k, self[k] in ['TRACE', 'DEBUG', 'INFO', 'WARN', 'ERROR', 'FATAL', 'OFF'])",message="Logger levels must be one of TRACE, DEBUG, INFO, WARN, ERROR, FATAL or OFF"
+	LoggerLevels map[string]string `json:"loggerLevels,omitempty"`
+
+	// State of the bindings keyed by binding name, i.e. input: PAUSED. Paused and
+	// stopped bindings are continued with RESUMED and STARTED respectively.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self.all(k, self[k] in ['STARTED', 'STOPPED', 'PAUSED', 'RESUMED'])",message="Binding states must be one of STARTED, STOPPED, PAUSED or RESUMED"
+	Bindings map[string]string `json:"bindings,omitempty"`
+}
+
+// StreamTopology describes a stream as an ordered list of apps
+type StreamTopology struct {
+	// Named destination the stream consumes from, rendered as ":<sourceDestination> >"
+	// +optional
+	SourceDestination *string `json:"sourceDestination,omitempty"`
+
+	// App of another stream, that this stream taps, rendered as ":<stream>.<app> >"
+	// +optional
+	Tap *StreamTap `json:"tap,omitempty"`
+
+	// Apps of the stream in the order they are piped together
+	// +optional
+	Apps []StreamApp `json:"apps,omitempty"`
+
+	// Named destination the stream produces to, rendered as "> :<sinkDestination>"
+	// +optional
+	SinkDestination *string `json:"sinkDestination,omitempty"`
+}
+
+// StreamTap references an app of another stream
+type StreamTap struct {
+	// Name of the tapped stream
+	// +kubebuilder:validation:Required
+	Stream string `json:"stream"`
+
+	// Label or name of the tapped app within the stream
+	// +kubebuilder:validation:Required
+	App string `json:"app"`
+}
+
+// StreamApp is a registered app used within a stream
+type StreamApp struct {
+	// Label of the app, required if the same app is used more than once in the stream
+	// +optional
+	Label *string `json:"label,omitempty"`
+
+	// Name of the registered app
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Properties of the app, rendered as --<key>=<value>
+	// +optional
+	Properties map[string]string `json:"properties,omitempty"`
 }
 
 // StreamObservation are the observable fields of a Stream.
@@ -55,6 +171,68 @@ type StreamObservation struct {
 	Definition        string `json:"definition"`
 	Status            string `json:"status"`
 	StatusDescription string `json:"statusDescription"`
+
+	// Name of the stream tapped by the definition
+	// +optional
+	TappedStream string `json:"tappedStream,omitempty"`
+
+	// Named destinations the definition consumes from or produces to
+	// +optional
+	NamedDestinations []string `json:"namedDestinations,omitempty"`
+
+	// Validation status of each app used by the stream as reported by the
+	// Data Flow server, keyed by <type>:<name>
+	// +optional
+	AppStatuses map[string]string `json:"appStatuses,omitempty"`
+
+	// Skipper platform the stream is deployed to, empty if it is not deployed
+	// +optional
+	Platform string `json:"platform,omitempty"`
+
+	// Versions of the apps the stream was deployed or updated with keyed by app
+	// label or name, only tracked with followDefaultVersions
+	// +optional
+	AppVersions map[string]string `json:"appVersions,omitempty"`
+
+	// Hash of the resolved deployment properties, that were applied last
+	// +optional
+	DeploymentPropertiesHash string `json:"deploymentPropertiesHash,omitempty"`
+
+	// Hash of the sensitive app arguments of the definition, that was
+	// created last. The server masks their values.
+	// +optional
+	SensitiveArgumentsHash string `json:"sensitiveArgumentsHash,omitempty"`
+
+	// Tail of the logs of the failing apps keyed by deployment id, captured
+	// while the deployment is failed or partial
+	// +optional
+	FailedAppLogs map[string]string `json:"failedAppLogs,omitempty"`
+
+	// Time the logs of the failing apps were captured
+	// +optional
+	FailedAppLogsTime *metav1.Time `json:"failedAppLogsTime,omitempty"`
+
+	// Results of applying the actuator settings to the running app instances
+	// +optional
+	ActuatorInstances []StreamActuatorInstance `json:"actuatorInstances,omitempty"`
+}
+
+// StreamActuatorInstance is the result of applying the actuator settings to a
+// running app instance
+type StreamActuatorInstance struct {
+	// Label or name of the app
+	App string `json:"app"`
+
+	// Id of the app instance
+	Instance string `json:"instance"`
+
+	// Hash of the actuator settings, that were applied successfully
+	// +optional
+	AppliedHash string `json:"appliedHash,omitempty"`
+
+	// Error of the last attempt to apply the settings
+	// +optional
+	Error string `json:"error,omitempty"`
 }
 
 // A StreamSpec defines the desired state of a Stream.
@@ -26,6 +26,7 @@ import (
 )
 
 // TaskDefinitionParameters are the configurable fields of a TaskDefinition.
+// +kubebuilder:validation:XValidation:rule="has(self.definition) != has(self.graph)",message="Exactly one of definition or graph is required"
 type TaskDefinitionParameters struct {
 
 	// Name of the task definition (immutable)
@@ -33,15 +34,127 @@ type TaskDefinitionParameters struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Name is immutable"
 	Name string `json:"name"`
 
-	// Description of the task definition (immutable)
+	// Description of the task definition. Changes recreate the definition.
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Description is immutable"
 	Description string `json:"description"`
 
-	// The definition for the task, using Data Flow DSL (immutable)
+	// The definition for the task, using Data Flow DSL. Changes recreate the definition,
+	// once no executions are running.
+	// Exactly one of definition or graph is required.
+	// +optional
+	Definition string `json:"definition,omitempty"`
+
+	// Structured definition for a composed task, that is rendered to Data Flow DSL.
+	// Changes recreate the definition, once no executions are running.
+	// Exactly one of definition or graph is required.
+	// +optional
+	Graph *ComposedTaskGraph `json:"graph,omitempty"`
+
+	// What happens to the executions, when the definition is deleted. None only deletes
+	// the definition, Cleanup also removes the resources launched on the platform and
+	// StopAndCleanup stops running executions first.
+	// +optional
+	// +kubebuilder:validation:Enum=None;Cleanup;StopAndCleanup
+	// +kubebuilder:default=None
+	CleanupOnDelete string `json:"cleanupOnDelete,omitempty"`
+
+	// Spring Batch job run by the task, its latest job executions are reported in the status
+	// +optional
+	BatchJob *BatchJob `json:"batchJob,omitempty"`
+}
+
+// ComposedTaskGraph describes a composed task as a flow of task apps, splits
+// and transitions
+type ComposedTaskGraph struct {
+	// Task apps of the composed task, each is used exactly once in the flow,
+	// a split or as transition target
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	Nodes []ComposedTaskNode `json:"nodes"`
+
+	// Steps of the composed task, that run one after another, rendered joined by " && "
+	// +kubebuilder:validation:MinItems=1
+	Flow []ComposedTaskStep `json:"flow"`
+}
+
+// ComposedTaskNode is a task app within a composed task
+type ComposedTaskNode struct {
+	// Name of the node, that is referenced by steps and transitions. It is rendered
+	// as label, if it differs from the app, and names the child task definition.
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Definition is immutable"
-	Definition string `json:"definition"`
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z][a-zA-Z0-9_-]*$`
+	Name string `json:"name"`
+
+	// Name of the registered task app
+	// +kubebuilder:validation:Required
+	App string `json:"app"`
+
+	// Properties of the app, rendered as --<key>=<value>
+	// +optional
+	Properties map[string]string `json:"properties,omitempty"`
+
+	// Transitions depending on the exit status of the app, rendered as '<exitStatus>'-><target>
+	// +optional
+	Transitions []ComposedTaskTransition `json:"transitions,omitempty"`
+}
+
+// ComposedTaskTransition continues with the target, if the app exits with the exit status
+type ComposedTaskTransition struct {
+	// Exit status of the app, * matches every status
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ExitStatus string `json:"exitStatus"`
+
+	// Name of the target node, $END or $FAIL. The target node must not have transitions.
+	// +kubebuilder:validation:Required
+	Target string `json:"target"`
+}
+
+// ComposedTaskStep runs either a node or a split
+// +kubebuilder:validation:XValidation:rule="has(self.node) != has(self.split)",message="Exactly one of node or split is required"
+type ComposedTaskStep struct {
+	// Name of the node
+	// +optional
+	Node *string `json:"node,omitempty"`
+
+	// Sequences of nodes, that run in parallel, rendered as "<a && b || c>"
+	// +optional
+	Split []ComposedTaskSequence `json:"split,omitempty"`
+}
+
+// ComposedTaskSequence is a sequence of nodes within a split
+type ComposedTaskSequence struct {
+	// Names of the nodes, that run one after another
+	// +kubebuilder:validation:MinItems=1
+	Nodes []string `json:"nodes"`
+}
+
+// BatchJob configures the observation and restart of the Spring Batch job run by a task
+type BatchJob struct {
+	// Name of the Spring Batch job, defaults to the name of the task definition
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Number of latest job executions reported in the status
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=50
+	// +kubebuilder:default=5
+	ExecutionsLimit int `json:"executionsLimit,omitempty"`
+
+	// Restarts failed job executions automatically, if set
+	// +optional
+	RestartFailedJobs *RestartFailedJobs `json:"restartFailedJobs,omitempty"`
+}
+
+// RestartFailedJobs is the policy for restarting failed job executions
+type RestartFailedJobs struct {
+	// Maximum number of restarts of a job instance
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=3
+	MaxAttempts int `json:"maxAttempts,omitempty"`
 }
 
 // TaskDefinitionObservation are the observable fields of a TaskDefinition.
@@ -52,6 +165,175 @@ type TaskDefinitionObservation struct {
 	Composed            bool   `json:"composed"`
 	ComposedTaskElement bool   `json:"composedTaskElement"`
 	Status              string `json:"status"`
+
+	// Child task definitions created by the server for each app of a composed task
+	// +optional
+	ComposedTaskElements []ComposedTaskElement `json:"composedTaskElements,omitempty"`
+
+	// Validation status of each app used by the task as reported by the
+	// Data Flow server, keyed by <type>:<name>
+	// +optional
+	AppStatuses map[string]string `json:"appStatuses,omitempty"`
+
+	// Summary of the last execution of the task
+	// +optional
+	LastExecution *TaskExecutionSummary `json:"lastExecution,omitempty"`
+
+	// Manifest the task was launched with last
+	// +optional
+	Manifest *TaskManifest `json:"manifest,omitempty"`
+
+	// Latest executions of the Spring Batch job, the most recent first
+	// +optional
+	JobExecutions []JobExecution `json:"jobExecutions,omitempty"`
+
+	// Restarts of failed job executions, the most recent first
+	// +optional
+	JobRestarts []JobRestart `json:"jobRestarts,omitempty"`
+
+	// Schedules, that were deleted by the server together with the definition,
+	// when it was recreated last
+	// +optional
+	DeletedSchedules []DeletedSchedule `json:"deletedSchedules,omitempty"`
+
+	// Hash of the sensitive app arguments of the definition, that was
+	// created last. The server masks their values.
+	// +optional
+	SensitiveArgumentsHash string `json:"sensitiveArgumentsHash,omitempty"`
+}
+
+// DeletedSchedule is a schedule, that was deleted together with its task definition
+type DeletedSchedule struct {
+	ScheduleName string `json:"scheduleName"`
+
+	// +optional
+	Platform string `json:"platform,omitempty"`
+
+	// +optional
+	CronExpression string `json:"cronExpression,omitempty"`
+
+	// Name of the TaskSchedule, that recreates the schedule. Schedules without
+	// a TaskSchedule have to be recreated manually.
+	// +optional
+	TaskSchedule string `json:"taskSchedule,omitempty"`
+
+	Time metav1.Time `json:"time"`
+}
+
+// ComposedTaskElement is a child task definition of a composed task
+type ComposedTaskElement struct {
+	// Name of the child task definition, <task>-<label or app>
+	Name string `json:"name"`
+
+	// Data Flow DSL of the child task definition
+	Definition string `json:"definition"`
+}
+
+// TaskExecutionSummary summarizes an execution of a task
+type TaskExecutionSummary struct {
+	ExecutionID int64 `json:"executionId"`
+
+	// Status of the execution, i.e. RUNNING, COMPLETE or ERROR
+	Status string `json:"status"`
+
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// +optional
+	ExitMessage string `json:"exitMessage,omitempty"`
+
+	// +optional
+	ErrorMessage string `json:"errorMessage,omitempty"`
+
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+
+	// Platform the execution was launched on
+	// +optional
+	PlatformName string `json:"platformName,omitempty"`
+}
+
+// TaskManifest is the app and the properties a task was launched with.
+// Sensitive values are masked by the Data Flow server.
+type TaskManifest struct {
+	// Uri of the launched app
+	// +optional
+	ResourceURL string `json:"resourceUrl,omitempty"`
+
+	// +optional
+	AppProperties map[string]string `json:"appProperties,omitempty"`
+
+	// +optional
+	DeploymentProperties map[string]string `json:"deploymentProperties,omitempty"`
+
+	// +optional
+	Arguments []string `json:"arguments,omitempty"`
+}
+
+// JobExecution is an execution of a Spring Batch job
+type JobExecution struct {
+	ExecutionID int64 `json:"executionId"`
+
+	// Id of the job instance, restarts of a job execution belong to the same instance
+	JobInstanceID int64 `json:"jobInstanceId"`
+
+	// +optional
+	TaskExecutionID int64 `json:"taskExecutionId,omitempty"`
+
+	// Batch status of the execution, i.e. COMPLETED, STARTED or FAILED
+	Status string `json:"status"`
+
+	// +optional
+	ExitCode string `json:"exitCode,omitempty"`
+
+	// +optional
+	ExitDescription string `json:"exitDescription,omitempty"`
+
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+
+	// +optional
+	Restartable bool `json:"restartable,omitempty"`
+
+	// +optional
+	Steps []JobStepExecution `json:"steps,omitempty"`
+
+	// Schema of the job repository, i.e. boot2 or boot3
+	// +optional
+	SchemaTarget string `json:"schemaTarget,omitempty"`
+}
+
+// JobStepExecution is the execution of a step of a Spring Batch job
+type JobStepExecution struct {
+	Name string `json:"name"`
+
+	Status string `json:"status"`
+
+	// +optional
+	ExitCode string `json:"exitCode,omitempty"`
+}
+
+// JobRestart is an attempt to restart a failed job execution
+type JobRestart struct {
+	// Id of the failed job execution, that was restarted
+	ExecutionID int64 `json:"executionId"`
+
+	JobInstanceID int64 `json:"jobInstanceId"`
+
+	// Number of the attempt for the job instance, starting at 1
+	Attempt int `json:"attempt"`
+
+	Time metav1.Time `json:"time"`
+
+	// Error of the restart request, if it failed
+	// +optional
+	Error string `json:"error,omitempty"`
 }
 
 // A TaskDefinitionSpec defines the desired state of a TaskDefinition.
@@ -72,6 +354,8 @@ type TaskDefinitionStatus struct {
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="VALIDATED",type="string",JSONPath=".status.conditions[?(@.type=='Validated')].status"
+// +kubebuilder:printcolumn:name="LAST-EXECUTION",type="string",JSONPath=".status.atProvider.lastExecution.status"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,springclouddataflow}
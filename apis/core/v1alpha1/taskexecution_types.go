@@ -0,0 +1,201 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TaskExecutionParameters are the configurable fields of a TaskExecution.
+type TaskExecutionParameters struct {
+
+	// TaskDefinition Name that will be launched (immutable)
+	// At least one of taskDefinitionName, taskDefinitionNameRef or taskDefinitionNameSelector is required.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="TaskDefinitionName is immutable"
+	// +crossplane:generate:reference:type=github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1.TaskDefinition
+	TaskDefinitionName *string `json:"taskDefinitionName,omitempty"`
+
+	// TaskDefinition reference to retrieve the TaskDefinition Name, that will be launched
+	// At least one of taskDefinitionName, taskDefinitionNameRef or taskDefinitionNameSelector is required.
+	// +optional
+	TaskDefinitionNameRef *xpv1.Reference `json:"taskDefinitionNameRef,omitempty"`
+
+	// TaskDefinitionNameSelector selects a reference to a TaskDefinition and retrieves its name
+	// +optional
+	TaskDefinitionNameSelector *xpv1.Selector `json:"taskDefinitionNameSelector,omitempty"`
+
+	// Command line arguments of the task, i.e. --input=/data. Changes launch the task again.
+	// +optional
+	Arguments []string `json:"arguments,omitempty"`
+
+	// Properties of the launch, i.e. app.<app>.<property> or deployer.<app>.<property>.
+	// Values can be read from Secrets or ConfigMaps. Changes, including changes of the
+	// values of referenced Secrets and ConfigMaps, launch the task again.
+	// +optional
+	Properties []Property `json:"properties,omitempty"`
+
+	// Kubernetes deployer settings keyed by task app label or name, rendered to
+	// deployer.<app>.kubernetes.* properties. Properties with the same key in
+	// properties take precedence. Changes launch the task again.
+	// +optional
+	KubernetesDeployer map[string]KubernetesDeployer `json:"kubernetesDeployer,omitempty"`
+
+	// Task platform the task is launched on. Changes launch the task again.
+	// +optional
+	// +kubebuilder:default=default
+	Platform string `json:"platform,omitempty"`
+
+	// Arbitrary run identifier, changing it launches the task again without changing
+	// arguments or properties, i.e. the version of a migration
+	// +optional
+	RunID *string `json:"runId,omitempty"`
+
+	// Whether the task is launched again while the current execution is still running.
+	// Forbid waits for the execution to complete, Replace stops it and Allow runs both.
+	// +optional
+	// +kubebuilder:validation:Enum=Forbid;Allow;Replace
+	// +kubebuilder:default=Forbid
+	ConcurrencyPolicy string `json:"concurrencyPolicy,omitempty"`
+
+	// Number of previous executions kept in the status
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=10
+	HistoryLimit int `json:"historyLimit,omitempty"`
+}
+
+// TaskExecutionObservation are the observable fields of a TaskExecution.
+type TaskExecutionObservation struct {
+	// Id of the task execution
+	// +optional
+	ExecutionID *int64 `json:"executionId,omitempty"`
+
+	// Name of the launched task definition
+	// +optional
+	TaskName string `json:"taskName,omitempty"`
+
+	// Status of the task execution, one of RUNNING, COMPLETE, ERROR or UNKNOWN
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+
+	// Exit code of the task, only set once it completed
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// +optional
+	ExitMessage string `json:"exitMessage,omitempty"`
+
+	// +optional
+	ErrorMessage string `json:"errorMessage,omitempty"`
+
+	// Id of the execution on the platform, i.e. the name of the pod
+	// +optional
+	ExternalExecutionID string `json:"externalExecutionId,omitempty"`
+
+	// Hash of the arguments, resolved properties, platform and runId of the current execution
+	// +optional
+	LaunchHash string `json:"launchHash,omitempty"`
+
+	// Previous executions, the most recent first
+	// +optional
+	History []TaskExecutionRecord `json:"history,omitempty"`
+}
+
+// TaskExecutionRecord is the outcome of a previous execution
+type TaskExecutionRecord struct {
+	ExecutionID int64 `json:"executionId"`
+
+	// Status of the execution, when it was superseded
+	Status string `json:"status"`
+
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// +optional
+	ExitMessage string `json:"exitMessage,omitempty"`
+
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+}
+
+// A TaskExecutionSpec defines the desired state of a TaskExecution.
+type TaskExecutionSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TaskExecutionParameters `json:"forProvider"`
+}
+
+// A TaskExecutionStatus represents the observed state of a TaskExecution.
+type TaskExecutionStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TaskExecutionObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A TaskExecution launches a task and tracks its execution. The task is
+// launched again, whenever its arguments, properties or runId change.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.status"
+// +kubebuilder:printcolumn:name="EXECUTION-ID",type="integer",JSONPath=".status.atProvider.executionId"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,springclouddataflow}
+type TaskExecution struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TaskExecutionSpec   `json:"spec"`
+	Status TaskExecutionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TaskExecutionList contains a list of TaskExecution
+type TaskExecutionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TaskExecution `json:"items"`
+}
+
+// TaskExecution type metadata.
+var (
+	TaskExecutionKind             = reflect.TypeOf(TaskExecution{}).Name()
+	TaskExecutionGroupKind        = schema.GroupKind{Group: Group, Kind: TaskExecutionKind}.String()
+	TaskExecutionKindAPIVersion   = TaskExecutionKind + "." + SchemeGroupVersion.String()
+	TaskExecutionGroupVersionKind = SchemeGroupVersion.WithKind(TaskExecutionKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&TaskExecution{}, &TaskExecutionList{})
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TaskExecutionRetentionParameters are the configurable fields of a TaskExecutionRetention.
+// +kubebuilder:validation:XValidation:rule="has(self.maxAgeDays) || has(self.keepLast)",message="At least one of maxAgeDays or keepLast is required"
+type TaskExecutionRetentionParameters struct {
+
+	// TaskDefinition Name, whose executions are cleaned up
+	// At least one of taskDefinitionName, taskDefinitionNameRef or taskDefinitionNameSelector is required.
+	// +kubebuilder:validation:Optional
+	// +crossplane:generate:reference:type=github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1.TaskDefinition
+	TaskDefinitionName *string `json:"taskDefinitionName,omitempty"`
+
+	// TaskDefinition reference to retrieve the TaskDefinition Name, whose executions are cleaned up
+	// At least one of taskDefinitionName, taskDefinitionNameRef or taskDefinitionNameSelector is required.
+	// +optional
+	TaskDefinitionNameRef *xpv1.Reference `json:"taskDefinitionNameRef,omitempty"`
+
+	// TaskDefinitionNameSelector selects a reference to a TaskDefinition and retrieves its name
+	// +optional
+	TaskDefinitionNameSelector *xpv1.Selector `json:"taskDefinitionNameSelector,omitempty"`
+
+	// Completed executions, that ended more than maxAgeDays ago, are removed
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxAgeDays *int32 `json:"maxAgeDays,omitempty"`
+
+	// Completed executions beyond the most recent keepLast completed executions
+	// are removed. Running executions are not counted.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	KeepLast *int32 `json:"keepLast,omitempty"`
+
+	// If true, the executions are only reported in the status, but not removed
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Interval between two cleanups, i.e. 30m or 24h
+	// +optional
+	// +kubebuilder:default="1h"
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// TaskExecutionRetentionObservation are the observable fields of a TaskExecutionRetention.
+type TaskExecutionRetentionObservation struct {
+	// +optional
+	LastCleanupTime *metav1.Time `json:"lastCleanupTime,omitempty"`
+
+	// Number of executions removed by the last cleanup or, in dry run mode, that
+	// would have been removed
+	// +optional
+	LastRemovedCount int `json:"lastRemovedCount,omitempty"`
+
+	// Ids of the executions removed by the last cleanup or, in dry run mode, that
+	// would have been removed. Limited to the first 100 ids.
+	// +optional
+	LastRemovedExecutionIDs []int64 `json:"lastRemovedExecutionIds,omitempty"`
+
+	// Number of executions removed by all cleanups
+	// +optional
+	TotalRemovedCount int64 `json:"totalRemovedCount,omitempty"`
+}
+
+// A TaskExecutionRetentionSpec defines the desired state of a TaskExecutionRetention.
+type TaskExecutionRetentionSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TaskExecutionRetentionParameters `json:"forProvider"`
+}
+
+// A TaskExecutionRetentionStatus represents the observed state of a TaskExecutionRetention.
+type TaskExecutionRetentionStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TaskExecutionRetentionObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A TaskExecutionRetention periodically removes old executions of a task.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="DRY-RUN",type="boolean",JSONPath=".spec.forProvider.dryRun"
+// +kubebuilder:printcolumn:name="REMOVED",type="integer",JSONPath=".status.atProvider.totalRemovedCount"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,springclouddataflow}
+type TaskExecutionRetention struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TaskExecutionRetentionSpec   `json:"spec"`
+	Status TaskExecutionRetentionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TaskExecutionRetentionList contains a list of TaskExecutionRetention
+type TaskExecutionRetentionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TaskExecutionRetention `json:"items"`
+}
+
+// TaskExecutionRetention type metadata.
+var (
+	TaskExecutionRetentionKind             = reflect.TypeOf(TaskExecutionRetention{}).Name()
+	TaskExecutionRetentionGroupKind        = schema.GroupKind{Group: Group, Kind: TaskExecutionRetentionKind}.String()
+	TaskExecutionRetentionKindAPIVersion   = TaskExecutionRetentionKind + "." + SchemeGroupVersion.String()
+	TaskExecutionRetentionGroupVersionKind = SchemeGroupVersion.WithKind(TaskExecutionRetentionKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&TaskExecutionRetention{}, &TaskExecutionRetentionList{})
+}
@@ -26,6 +26,7 @@ import (
 )
 
 // TaskScheduleParameters are the configurable fields of a TaskSchedule.
+// +kubebuilder:validation:XValidation:rule="!has(self.arguments) || !has(self.argumentList)",message="arguments is deprecated and can not be combined with argumentList"
 type TaskScheduleParameters struct {
 
 	// Name of the task schedule (immutable)
@@ -52,21 +53,55 @@ type TaskScheduleParameters struct {
 	// +optional
 	TaskDefinitionNameSelector *xpv1.Selector `json:"taskDefinitionNameSelector,omitempty"`
 
-	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Platform is immutable"
+	// Cron expression of the schedule. Kubernetes expects 5 fields (minute hour day-of-month
+	// month day-of-week), other schedulers 6 fields in Quartz format with seconds.
+	// Changes reschedule the task.
 	// +kubebuilder:validation:Required
 	CronExpression string `json:"cronExpression,omitempty"`
 
+	// Time zone of the cron expression from the tz database, i.e. Europe/Berlin.
+	// Only supported by Kubernetes, where it is set on the CronJob. Defaults to
+	// the time zone of the scheduler. Changes reschedule the task.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Platform is immutable"
 	// +kubebuilder:default=default
 	Platform string `json:"platform,omitempty"`
 
+	// Deprecated: Use argumentList. Command line arguments of the task separated by spaces.
 	// +optional
-	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Arguments is immutable"
 	Arguments *string `json:"arguments,omitempty"`
 
+	// Command line arguments of the task. Changes reschedule the task.
+	// +optional
+	ArgumentList []string `json:"argumentList,omitempty"`
+
+	// Deprecated: Use propertyMap. Comma separated key=value properties of the schedule.
 	// +optional
-	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Properties is immutable"
 	Properties *string `json:"properties,omitempty"`
+
+	// Properties of the schedule, i.e. scheduler.kubernetes.jobAnnotations. Values may
+	// contain commas and equals signs. Changes reschedule the task.
+	// +optional
+	PropertyMap map[string]string `json:"propertyMap,omitempty"`
+
+	// Properties of the schedule in addition to properties, whose values can be read
+	// from Secrets or ConfigMaps. Changes, including changes of referenced Secrets and
+	// ConfigMaps, reschedule the task.
+	// +optional
+	PropertiesFrom []Property `json:"propertiesFrom,omitempty"`
+
+	// Kubernetes deployer settings keyed by task app label or name, rendered to
+	// deployer.<app>.kubernetes.* properties. Properties with the same key in
+	// propertiesFrom take precedence. Changes reschedule the task.
+	// +optional
+	KubernetesDeployer map[string]KubernetesDeployer `json:"kubernetesDeployer,omitempty"`
+
+	// Suspend removes the schedule from the server, while keeping the resource.
+	// Setting it back to false schedules the task again.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
 }
 
 // TaskScheduleObservation are the observable fields of a TaskSchedule.
@@ -74,6 +109,41 @@ type TaskScheduleObservation struct {
 	ScheduleName string `json:"scheduleName"`
 
 	TaskDefinitionName *string `json:"taskDefinitionName,omitempty"`
+
+	// Cron expression of the schedule on the server
+	// +optional
+	CronExpression string `json:"cronExpression,omitempty"`
+
+	// Time zone of the schedule on the server. For schedulers, that do not
+	// report it, the time zone that was applied last.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Arguments, that were applied last. They are not reported by the server.
+	// +optional
+	Arguments *string `json:"arguments,omitempty"`
+
+	// Properties of the schedule on the server, without the cron expression and
+	// propertiesFrom. For schedulers, that do not report them, the properties
+	// that were applied last.
+	// +optional
+	Properties map[string]string `json:"properties,omitempty"`
+
+	// Whether the schedule is suspended and removed from the server
+	// +optional
+	Suspended bool `json:"suspended,omitempty"`
+
+	// Time when the schedule was suspended
+	// +optional
+	SuspendedTime *metav1.Time `json:"suspendedTime,omitempty"`
+
+	// Next fire times of the cron expression
+	// +optional
+	NextScheduledTimes []metav1.Time `json:"nextScheduledTimes,omitempty"`
+
+	// Hash of the resolved propertiesFrom and kubernetesDeployer, that were applied last
+	// +optional
+	PropertiesHash string `json:"propertiesHash,omitempty"`
 }
 
 // A TaskScheduleSpec defines the desired state of a TaskSchedule.
@@ -94,6 +164,8 @@ type TaskScheduleStatus struct {
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="SUSPENDED",type="boolean",JSONPath=".status.atProvider.suspended"
+// +kubebuilder:printcolumn:name="NEXT-SCHEDULED",type="string",JSONPath=".status.atProvider.nextScheduledTimes[0]"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,springclouddataflow}
@@ -23,6 +23,7 @@ package v1alpha1
 
 import (
 	"github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -149,6 +150,464 @@ func (in *ApplicationStatus) DeepCopy() *ApplicationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BatchJob) DeepCopyInto(out *BatchJob) {
+	*out = *in
+	if in.RestartFailedJobs != nil {
+		in, out := &in.RestartFailedJobs, &out.RestartFailedJobs
+		*out = new(RestartFailedJobs)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchJob.
+func (in *BatchJob) DeepCopy() *BatchJob {
+	if in == nil {
+		return nil
+	}
+	out := new(BatchJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComposedTaskElement) DeepCopyInto(out *ComposedTaskElement) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposedTaskElement.
+func (in *ComposedTaskElement) DeepCopy() *ComposedTaskElement {
+	if in == nil {
+		return nil
+	}
+	out := new(ComposedTaskElement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComposedTaskGraph) DeepCopyInto(out *ComposedTaskGraph) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]ComposedTaskNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Flow != nil {
+		in, out := &in.Flow, &out.Flow
+		*out = make([]ComposedTaskStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposedTaskGraph.
+func (in *ComposedTaskGraph) DeepCopy() *ComposedTaskGraph {
+	if in == nil {
+		return nil
+	}
+	out := new(ComposedTaskGraph)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComposedTaskNode) DeepCopyInto(out *ComposedTaskNode) {
+	*out = *in
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Transitions != nil {
+		in, out := &in.Transitions, &out.Transitions
+		*out = make([]ComposedTaskTransition, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposedTaskNode.
+func (in *ComposedTaskNode) DeepCopy() *ComposedTaskNode {
+	if in == nil {
+		return nil
+	}
+	out := new(ComposedTaskNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComposedTaskSequence) DeepCopyInto(out *ComposedTaskSequence) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposedTaskSequence.
+func (in *ComposedTaskSequence) DeepCopy() *ComposedTaskSequence {
+	if in == nil {
+		return nil
+	}
+	out := new(ComposedTaskSequence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComposedTaskStep) DeepCopyInto(out *ComposedTaskStep) {
+	*out = *in
+	if in.Node != nil {
+		in, out := &in.Node, &out.Node
+		*out = new(string)
+		**out = **in
+	}
+	if in.Split != nil {
+		in, out := &in.Split, &out.Split
+		*out = make([]ComposedTaskSequence, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposedTaskStep.
+func (in *ComposedTaskStep) DeepCopy() *ComposedTaskStep {
+	if in == nil {
+		return nil
+	}
+	out := new(ComposedTaskStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComposedTaskTransition) DeepCopyInto(out *ComposedTaskTransition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposedTaskTransition.
+func (in *ComposedTaskTransition) DeepCopy() *ComposedTaskTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(ComposedTaskTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeySelector) DeepCopyInto(out *ConfigMapKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeySelector.
+func (in *ConfigMapKeySelector) DeepCopy() *ConfigMapKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeletedSchedule) DeepCopyInto(out *DeletedSchedule) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletedSchedule.
+func (in *DeletedSchedule) DeepCopy() *DeletedSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(DeletedSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobExecution) DeepCopyInto(out *JobExecution) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]JobStepExecution, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobExecution.
+func (in *JobExecution) DeepCopy() *JobExecution {
+	if in == nil {
+		return nil
+	}
+	out := new(JobExecution)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobRestart) DeepCopyInto(out *JobRestart) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobRestart.
+func (in *JobRestart) DeepCopy() *JobRestart {
+	if in == nil {
+		return nil
+	}
+	out := new(JobRestart)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobStepExecution) DeepCopyInto(out *JobStepExecution) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobStepExecution.
+func (in *JobStepExecution) DeepCopy() *JobStepExecution {
+	if in == nil {
+		return nil
+	}
+	out := new(JobStepExecution)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesDeployer) DeepCopyInto(out *KubernetesDeployer) {
+	*out = *in
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(KubernetesResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = new(KubernetesResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnvironmentVariables != nil {
+		in, out := &in.EnvironmentVariables, &out.EnvironmentVariables
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SecretRefs != nil {
+		in, out := &in.SecretRefs, &out.SecretRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigMapRefs != nil {
+		in, out := &in.ConfigMapRefs, &out.ConfigMapRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DeploymentLabels != nil {
+		in, out := &in.DeploymentLabels, &out.DeploymentLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]KubernetesToleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceAccountName != nil {
+		in, out := &in.ServiceAccountName, &out.ServiceAccountName
+		*out = new(string)
+		**out = **in
+	}
+	if in.ImagePullPolicy != nil {
+		in, out := &in.ImagePullPolicy, &out.ImagePullPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.ImagePullSecret != nil {
+		in, out := &in.ImagePullSecret, &out.ImagePullSecret
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesDeployer.
+func (in *KubernetesDeployer) DeepCopy() *KubernetesDeployer {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesDeployer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesResources) DeepCopyInto(out *KubernetesResources) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		*out = new(string)
+		**out = **in
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesResources.
+func (in *KubernetesResources) DeepCopy() *KubernetesResources {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesToleration) DeepCopyInto(out *KubernetesToleration) {
+	*out = *in
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(string)
+		**out = **in
+	}
+	if in.Operator != nil {
+		in, out := &in.Operator, &out.Operator
+		*out = new(string)
+		**out = **in
+	}
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+	if in.Effect != nil {
+		in, out := &in.Effect, &out.Effect
+		*out = new(string)
+		**out = **in
+	}
+	if in.TolerationSeconds != nil {
+		in, out := &in.TolerationSeconds, &out.TolerationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesToleration.
+func (in *KubernetesToleration) DeepCopy() *KubernetesToleration {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesToleration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Property) DeepCopyInto(out *Property) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(PropertyValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Property.
+func (in *Property) DeepCopy() *Property {
+	if in == nil {
+		return nil
+	}
+	out := new(Property)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PropertyValueSource) DeepCopyInto(out *PropertyValueSource) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ConfigMapKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropertyValueSource.
+func (in *PropertyValueSource) DeepCopy() *PropertyValueSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PropertyValueSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestartFailedJobs) DeepCopyInto(out *RestartFailedJobs) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartFailedJobs.
+func (in *RestartFailedJobs) DeepCopy() *RestartFailedJobs {
+	if in == nil {
+		return nil
+	}
+	out := new(RestartFailedJobs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Stream) DeepCopyInto(out *Stream) {
 	*out = *in
@@ -158,18 +617,516 @@ func (in *Stream) DeepCopyInto(out *Stream) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Stream.
-func (in *Stream) DeepCopy() *Stream {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Stream.
+func (in *Stream) DeepCopy() *Stream {
+	if in == nil {
+		return nil
+	}
+	out := new(Stream)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Stream) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamActuatorInstance) DeepCopyInto(out *StreamActuatorInstance) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamActuatorInstance.
+func (in *StreamActuatorInstance) DeepCopy() *StreamActuatorInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamActuatorInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamApp) DeepCopyInto(out *StreamApp) {
+	*out = *in
+	if in.Label != nil {
+		in, out := &in.Label, &out.Label
+		*out = new(string)
+		**out = **in
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamApp.
+func (in *StreamApp) DeepCopy() *StreamApp {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamApp)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamAppActuator) DeepCopyInto(out *StreamAppActuator) {
+	*out = *in
+	if in.LoggerLevels != nil {
+		in, out := &in.LoggerLevels, &out.LoggerLevels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Bindings != nil {
+		in, out := &in.Bindings, &out.Bindings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamAppActuator.
+func (in *StreamAppActuator) DeepCopy() *StreamAppActuator {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamAppActuator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamList) DeepCopyInto(out *StreamList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Stream, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamList.
+func (in *StreamList) DeepCopy() *StreamList {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StreamList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamObservation) DeepCopyInto(out *StreamObservation) {
+	*out = *in
+	if in.NamedDestinations != nil {
+		in, out := &in.NamedDestinations, &out.NamedDestinations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AppStatuses != nil {
+		in, out := &in.AppStatuses, &out.AppStatuses
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AppVersions != nil {
+		in, out := &in.AppVersions, &out.AppVersions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FailedAppLogs != nil {
+		in, out := &in.FailedAppLogs, &out.FailedAppLogs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FailedAppLogsTime != nil {
+		in, out := &in.FailedAppLogsTime, &out.FailedAppLogsTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ActuatorInstances != nil {
+		in, out := &in.ActuatorInstances, &out.ActuatorInstances
+		*out = make([]StreamActuatorInstance, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamObservation.
+func (in *StreamObservation) DeepCopy() *StreamObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamParameters) DeepCopyInto(out *StreamParameters) {
+	*out = *in
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = new(StreamTopology)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TappedStreamName != nil {
+		in, out := &in.TappedStreamName, &out.TappedStreamName
+		*out = new(string)
+		**out = **in
+	}
+	if in.TappedStreamNameRef != nil {
+		in, out := &in.TappedStreamNameRef, &out.TappedStreamNameRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TappedStreamNameSelector != nil {
+		in, out := &in.TappedStreamNameSelector, &out.TappedStreamNameSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeploymentProperties != nil {
+		in, out := &in.DeploymentProperties, &out.DeploymentProperties
+		*out = make([]Property, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.KubernetesDeployer != nil {
+		in, out := &in.KubernetesDeployer, &out.KubernetesDeployer
+		*out = make(map[string]KubernetesDeployer, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Actuator != nil {
+		in, out := &in.Actuator, &out.Actuator
+		*out = make(map[string]StreamAppActuator, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamParameters.
+func (in *StreamParameters) DeepCopy() *StreamParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamSpec) DeepCopyInto(out *StreamSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamSpec.
+func (in *StreamSpec) DeepCopy() *StreamSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamStatus) DeepCopyInto(out *StreamStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamStatus.
+func (in *StreamStatus) DeepCopy() *StreamStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamTap) DeepCopyInto(out *StreamTap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamTap.
+func (in *StreamTap) DeepCopy() *StreamTap {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamTap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamTopology) DeepCopyInto(out *StreamTopology) {
+	*out = *in
+	if in.SourceDestination != nil {
+		in, out := &in.SourceDestination, &out.SourceDestination
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tap != nil {
+		in, out := &in.Tap, &out.Tap
+		*out = new(StreamTap)
+		**out = **in
+	}
+	if in.Apps != nil {
+		in, out := &in.Apps, &out.Apps
+		*out = make([]StreamApp, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SinkDestination != nil {
+		in, out := &in.SinkDestination, &out.SinkDestination
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamTopology.
+func (in *StreamTopology) DeepCopy() *StreamTopology {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamTopology)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskDefinition) DeepCopyInto(out *TaskDefinition) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskDefinition.
+func (in *TaskDefinition) DeepCopy() *TaskDefinition {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TaskDefinition) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskDefinitionList) DeepCopyInto(out *TaskDefinitionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TaskDefinition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskDefinitionList.
+func (in *TaskDefinitionList) DeepCopy() *TaskDefinitionList {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskDefinitionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TaskDefinitionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskDefinitionObservation) DeepCopyInto(out *TaskDefinitionObservation) {
+	*out = *in
+	if in.ComposedTaskElements != nil {
+		in, out := &in.ComposedTaskElements, &out.ComposedTaskElements
+		*out = make([]ComposedTaskElement, len(*in))
+		copy(*out, *in)
+	}
+	if in.AppStatuses != nil {
+		in, out := &in.AppStatuses, &out.AppStatuses
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LastExecution != nil {
+		in, out := &in.LastExecution, &out.LastExecution
+		*out = new(TaskExecutionSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Manifest != nil {
+		in, out := &in.Manifest, &out.Manifest
+		*out = new(TaskManifest)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JobExecutions != nil {
+		in, out := &in.JobExecutions, &out.JobExecutions
+		*out = make([]JobExecution, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.JobRestarts != nil {
+		in, out := &in.JobRestarts, &out.JobRestarts
+		*out = make([]JobRestart, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DeletedSchedules != nil {
+		in, out := &in.DeletedSchedules, &out.DeletedSchedules
+		*out = make([]DeletedSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskDefinitionObservation.
+func (in *TaskDefinitionObservation) DeepCopy() *TaskDefinitionObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskDefinitionObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskDefinitionParameters) DeepCopyInto(out *TaskDefinitionParameters) {
+	*out = *in
+	if in.Graph != nil {
+		in, out := &in.Graph, &out.Graph
+		*out = new(ComposedTaskGraph)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BatchJob != nil {
+		in, out := &in.BatchJob, &out.BatchJob
+		*out = new(BatchJob)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskDefinitionParameters.
+func (in *TaskDefinitionParameters) DeepCopy() *TaskDefinitionParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskDefinitionParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskDefinitionSpec) DeepCopyInto(out *TaskDefinitionSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskDefinitionSpec.
+func (in *TaskDefinitionSpec) DeepCopy() *TaskDefinitionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskDefinitionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskDefinitionStatus) DeepCopyInto(out *TaskDefinitionStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskDefinitionStatus.
+func (in *TaskDefinitionStatus) DeepCopy() *TaskDefinitionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskDefinitionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskExecution) DeepCopyInto(out *TaskExecution) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskExecution.
+func (in *TaskExecution) DeepCopy() *TaskExecution {
 	if in == nil {
 		return nil
 	}
-	out := new(Stream)
+	out := new(TaskExecution)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Stream) DeepCopyObject() runtime.Object {
+func (in *TaskExecution) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -177,31 +1134,31 @@ func (in *Stream) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StreamList) DeepCopyInto(out *StreamList) {
+func (in *TaskExecutionList) DeepCopyInto(out *TaskExecutionList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Stream, len(*in))
+		*out = make([]TaskExecution, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamList.
-func (in *StreamList) DeepCopy() *StreamList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskExecutionList.
+func (in *TaskExecutionList) DeepCopy() *TaskExecutionList {
 	if in == nil {
 		return nil
 	}
-	out := new(StreamList)
+	out := new(TaskExecutionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *StreamList) DeepCopyObject() runtime.Object {
+func (in *TaskExecutionList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -209,71 +1166,129 @@ func (in *StreamList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StreamObservation) DeepCopyInto(out *StreamObservation) {
+func (in *TaskExecutionObservation) DeepCopyInto(out *TaskExecutionObservation) {
 	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamObservation.
-func (in *StreamObservation) DeepCopy() *StreamObservation {
-	if in == nil {
-		return nil
+	if in.ExecutionID != nil {
+		in, out := &in.ExecutionID, &out.ExecutionID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]TaskExecutionRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	out := new(StreamObservation)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StreamParameters) DeepCopyInto(out *StreamParameters) {
-	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamParameters.
-func (in *StreamParameters) DeepCopy() *StreamParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskExecutionObservation.
+func (in *TaskExecutionObservation) DeepCopy() *TaskExecutionObservation {
 	if in == nil {
 		return nil
 	}
-	out := new(StreamParameters)
+	out := new(TaskExecutionObservation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StreamSpec) DeepCopyInto(out *StreamSpec) {
+func (in *TaskExecutionParameters) DeepCopyInto(out *TaskExecutionParameters) {
 	*out = *in
-	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
-	out.ForProvider = in.ForProvider
+	if in.TaskDefinitionName != nil {
+		in, out := &in.TaskDefinitionName, &out.TaskDefinitionName
+		*out = new(string)
+		**out = **in
+	}
+	if in.TaskDefinitionNameRef != nil {
+		in, out := &in.TaskDefinitionNameRef, &out.TaskDefinitionNameRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TaskDefinitionNameSelector != nil {
+		in, out := &in.TaskDefinitionNameSelector, &out.TaskDefinitionNameSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make([]Property, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.KubernetesDeployer != nil {
+		in, out := &in.KubernetesDeployer, &out.KubernetesDeployer
+		*out = make(map[string]KubernetesDeployer, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.RunID != nil {
+		in, out := &in.RunID, &out.RunID
+		*out = new(string)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamSpec.
-func (in *StreamSpec) DeepCopy() *StreamSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskExecutionParameters.
+func (in *TaskExecutionParameters) DeepCopy() *TaskExecutionParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(StreamSpec)
+	out := new(TaskExecutionParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StreamStatus) DeepCopyInto(out *StreamStatus) {
+func (in *TaskExecutionRecord) DeepCopyInto(out *TaskExecutionRecord) {
 	*out = *in
-	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamStatus.
-func (in *StreamStatus) DeepCopy() *StreamStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskExecutionRecord.
+func (in *TaskExecutionRecord) DeepCopy() *TaskExecutionRecord {
 	if in == nil {
 		return nil
 	}
-	out := new(StreamStatus)
+	out := new(TaskExecutionRecord)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TaskDefinition) DeepCopyInto(out *TaskDefinition) {
+func (in *TaskExecutionRetention) DeepCopyInto(out *TaskExecutionRetention) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -281,18 +1296,18 @@ func (in *TaskDefinition) DeepCopyInto(out *TaskDefinition) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskDefinition.
-func (in *TaskDefinition) DeepCopy() *TaskDefinition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskExecutionRetention.
+func (in *TaskExecutionRetention) DeepCopy() *TaskExecutionRetention {
 	if in == nil {
 		return nil
 	}
-	out := new(TaskDefinition)
+	out := new(TaskExecutionRetention)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TaskDefinition) DeepCopyObject() runtime.Object {
+func (in *TaskExecutionRetention) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -300,31 +1315,31 @@ func (in *TaskDefinition) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TaskDefinitionList) DeepCopyInto(out *TaskDefinitionList) {
+func (in *TaskExecutionRetentionList) DeepCopyInto(out *TaskExecutionRetentionList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]TaskDefinition, len(*in))
+		*out = make([]TaskExecutionRetention, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskDefinitionList.
-func (in *TaskDefinitionList) DeepCopy() *TaskDefinitionList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskExecutionRetentionList.
+func (in *TaskExecutionRetentionList) DeepCopy() *TaskExecutionRetentionList {
 	if in == nil {
 		return nil
 	}
-	out := new(TaskDefinitionList)
+	out := new(TaskExecutionRetentionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TaskDefinitionList) DeepCopyObject() runtime.Object {
+func (in *TaskExecutionRetentionList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -332,65 +1347,200 @@ func (in *TaskDefinitionList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TaskDefinitionObservation) DeepCopyInto(out *TaskDefinitionObservation) {
+func (in *TaskExecutionRetentionObservation) DeepCopyInto(out *TaskExecutionRetentionObservation) {
 	*out = *in
+	if in.LastCleanupTime != nil {
+		in, out := &in.LastCleanupTime, &out.LastCleanupTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRemovedExecutionIDs != nil {
+		in, out := &in.LastRemovedExecutionIDs, &out.LastRemovedExecutionIDs
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskDefinitionObservation.
-func (in *TaskDefinitionObservation) DeepCopy() *TaskDefinitionObservation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskExecutionRetentionObservation.
+func (in *TaskExecutionRetentionObservation) DeepCopy() *TaskExecutionRetentionObservation {
 	if in == nil {
 		return nil
 	}
-	out := new(TaskDefinitionObservation)
+	out := new(TaskExecutionRetentionObservation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TaskDefinitionParameters) DeepCopyInto(out *TaskDefinitionParameters) {
+func (in *TaskExecutionRetentionParameters) DeepCopyInto(out *TaskExecutionRetentionParameters) {
 	*out = *in
+	if in.TaskDefinitionName != nil {
+		in, out := &in.TaskDefinitionName, &out.TaskDefinitionName
+		*out = new(string)
+		**out = **in
+	}
+	if in.TaskDefinitionNameRef != nil {
+		in, out := &in.TaskDefinitionNameRef, &out.TaskDefinitionNameRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TaskDefinitionNameSelector != nil {
+		in, out := &in.TaskDefinitionNameSelector, &out.TaskDefinitionNameSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxAgeDays != nil {
+		in, out := &in.MaxAgeDays, &out.MaxAgeDays
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepLast != nil {
+		in, out := &in.KeepLast, &out.KeepLast
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskDefinitionParameters.
-func (in *TaskDefinitionParameters) DeepCopy() *TaskDefinitionParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskExecutionRetentionParameters.
+func (in *TaskExecutionRetentionParameters) DeepCopy() *TaskExecutionRetentionParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(TaskDefinitionParameters)
+	out := new(TaskExecutionRetentionParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TaskDefinitionSpec) DeepCopyInto(out *TaskDefinitionSpec) {
+func (in *TaskExecutionRetentionSpec) DeepCopyInto(out *TaskExecutionRetentionSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
-	out.ForProvider = in.ForProvider
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskDefinitionSpec.
-func (in *TaskDefinitionSpec) DeepCopy() *TaskDefinitionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskExecutionRetentionSpec.
+func (in *TaskExecutionRetentionSpec) DeepCopy() *TaskExecutionRetentionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TaskDefinitionSpec)
+	out := new(TaskExecutionRetentionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TaskDefinitionStatus) DeepCopyInto(out *TaskDefinitionStatus) {
+func (in *TaskExecutionRetentionStatus) DeepCopyInto(out *TaskExecutionRetentionStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskDefinitionStatus.
-func (in *TaskDefinitionStatus) DeepCopy() *TaskDefinitionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskExecutionRetentionStatus.
+func (in *TaskExecutionRetentionStatus) DeepCopy() *TaskExecutionRetentionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(TaskDefinitionStatus)
+	out := new(TaskExecutionRetentionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskExecutionSpec) DeepCopyInto(out *TaskExecutionSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskExecutionSpec.
+func (in *TaskExecutionSpec) DeepCopy() *TaskExecutionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskExecutionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskExecutionStatus) DeepCopyInto(out *TaskExecutionStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskExecutionStatus.
+func (in *TaskExecutionStatus) DeepCopy() *TaskExecutionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskExecutionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskExecutionSummary) DeepCopyInto(out *TaskExecutionSummary) {
+	*out = *in
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskExecutionSummary.
+func (in *TaskExecutionSummary) DeepCopy() *TaskExecutionSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskExecutionSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskManifest) DeepCopyInto(out *TaskManifest) {
+	*out = *in
+	if in.AppProperties != nil {
+		in, out := &in.AppProperties, &out.AppProperties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DeploymentProperties != nil {
+		in, out := &in.DeploymentProperties, &out.DeploymentProperties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskManifest.
+func (in *TaskManifest) DeepCopy() *TaskManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskManifest)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -462,6 +1612,29 @@ func (in *TaskScheduleObservation) DeepCopyInto(out *TaskScheduleObservation) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = new(string)
+		**out = **in
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SuspendedTime != nil {
+		in, out := &in.SuspendedTime, &out.SuspendedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduledTimes != nil {
+		in, out := &in.NextScheduledTimes, &out.NextScheduledTimes
+		*out = make([]metav1.Time, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskScheduleObservation.
@@ -497,11 +1670,37 @@ func (in *TaskScheduleParameters) DeepCopyInto(out *TaskScheduleParameters) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ArgumentList != nil {
+		in, out := &in.ArgumentList, &out.ArgumentList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Properties != nil {
 		in, out := &in.Properties, &out.Properties
 		*out = new(string)
 		**out = **in
 	}
+	if in.PropertyMap != nil {
+		in, out := &in.PropertyMap, &out.PropertyMap
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PropertiesFrom != nil {
+		in, out := &in.PropertiesFrom, &out.PropertiesFrom
+		*out = make([]Property, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.KubernetesDeployer != nil {
+		in, out := &in.KubernetesDeployer, &out.KubernetesDeployer
+		*out = make(map[string]KubernetesDeployer, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskScheduleParameters.
@@ -46,6 +46,24 @@ func (l *TaskDefinitionList) GetItems() []resource.Managed {
 	return items
 }
 
+// GetItems of this TaskExecutionList.
+func (l *TaskExecutionList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
+// GetItems of this TaskExecutionRetentionList.
+func (l *TaskExecutionRetentionList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
 // GetItems of this TaskScheduleList.
 func (l *TaskScheduleList) GetItems() []resource.Managed {
 	items := make([]resource.Managed, len(l.Items))
@@ -24,6 +24,84 @@ import (
 	client "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// ResolveReferences of this Stream.
+func (mg *Stream) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	var rsp reference.ResolutionResponse
+	var err error
+
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.TappedStreamName),
+		Extract:      reference.ExternalName(),
+		Reference:    mg.Spec.ForProvider.TappedStreamNameRef,
+		Selector:     mg.Spec.ForProvider.TappedStreamNameSelector,
+		To: reference.To{
+			List:    &StreamList{},
+			Managed: &Stream{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "mg.Spec.ForProvider.TappedStreamName")
+	}
+	mg.Spec.ForProvider.TappedStreamName = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.TappedStreamNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this TaskExecution.
+func (mg *TaskExecution) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	var rsp reference.ResolutionResponse
+	var err error
+
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.TaskDefinitionName),
+		Extract:      reference.ExternalName(),
+		Reference:    mg.Spec.ForProvider.TaskDefinitionNameRef,
+		Selector:     mg.Spec.ForProvider.TaskDefinitionNameSelector,
+		To: reference.To{
+			List:    &TaskDefinitionList{},
+			Managed: &TaskDefinition{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "mg.Spec.ForProvider.TaskDefinitionName")
+	}
+	mg.Spec.ForProvider.TaskDefinitionName = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.TaskDefinitionNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this TaskExecutionRetention.
+func (mg *TaskExecutionRetention) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	var rsp reference.ResolutionResponse
+	var err error
+
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.TaskDefinitionName),
+		Extract:      reference.ExternalName(),
+		Reference:    mg.Spec.ForProvider.TaskDefinitionNameRef,
+		Selector:     mg.Spec.ForProvider.TaskDefinitionNameSelector,
+		To: reference.To{
+			List:    &TaskDefinitionList{},
+			Managed: &TaskDefinition{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "mg.Spec.ForProvider.TaskDefinitionName")
+	}
+	mg.Spec.ForProvider.TaskDefinitionName = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.TaskDefinitionNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
 // ResolveReferences of this TaskSchedule.
 func (mg *TaskSchedule) ResolveReferences(ctx context.Context, c client.Reader) error {
 	r := reference.NewAPIResolver(c, mg)
@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -38,7 +39,9 @@ import (
 
 	"github.com/denniskniep/provider-springclouddataflow/apis"
 	"github.com/denniskniep/provider-springclouddataflow/apis/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients"
 	springclouddataflow "github.com/denniskniep/provider-springclouddataflow/internal/controller"
+	"github.com/denniskniep/provider-springclouddataflow/internal/controllersdk"
 	"github.com/denniskniep/provider-springclouddataflow/internal/features"
 )
 
@@ -55,6 +58,7 @@ func main() {
 		namespace                  = app.Flag("namespace", "Namespace used to set as default scope in default secret store config.").Default("crossplane-system").Envar("POD_NAMESPACE").String()
 		enableExternalSecretStores = app.Flag("enable-external-secret-stores", "Enable support for ExternalSecretStores.").Default("false").Envar("ENABLE_EXTERNAL_SECRET_STORES").Bool()
 		enableManagementPolicies   = app.Flag("enable-management-policies", "Enable support for Management Policies.").Default("false").Envar("ENABLE_MANAGEMENT_POLICIES").Bool()
+		hashKeySecret              = app.Flag("hash-key-secret", "Secret in the provider namespace with the key of the property hashes in the status, created if it does not exist.").Default("provider-springclouddataflow-hash-key").Envar("HASH_KEY_SECRET").String()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -70,7 +74,14 @@ func main() {
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
 
-	mgr, err := ctrl.NewManager(ratelimiter.LimitRESTConfig(cfg, *maxReconcileRate), ctrl.Options{
+	// The manager's client can not be used before the manager is started
+	kube, err := client.New(cfg, client.Options{})
+	kingpin.FatalIfError(err, "Cannot create API server client")
+	hashKey, err := controllersdk.LoadHashKey(context.Background(), kube, *namespace, *hashKeySecret)
+	kingpin.FatalIfError(err, "Cannot load hash key")
+	clients.SetHashKey(hashKey)
+
+	mgr, err := ctrl.NewManager(ratelimiter.LimitRESTConfig(cfg, *maxReconcileRate), controllersdk.PropertySourceCache(ctrl.Options{
 		SyncPeriod: syncInterval,
 
 		// controller-runtime uses both ConfigMaps and Leases for leader
@@ -85,7 +96,7 @@ func main() {
 		LeaderElectionResourceLock: resourcelock.LeasesResourceLock,
 		LeaseDuration:              func() *time.Duration { d := 60 * time.Second; return &d }(),
 		RenewDeadline:              func() *time.Duration { d := 50 * time.Second; return &d }(),
-	})
+	}))
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add SpringCloudDataFlow APIs to scheme")
 
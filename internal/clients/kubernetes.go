@@ -0,0 +1,142 @@
+package clients
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+)
+
+const (
+	errEnvironmentValue = "environment variable '%s' of app '%s' contains a comma and a single quote, which the deployer cannot separate"
+)
+
+// KubernetesDeployerProperties renders the Kubernetes deployer settings of
+// all apps, keyed by app label or name, to deployer.<app>.kubernetes.*
+// properties
+func KubernetesDeployerProperties(deployers map[string]core.KubernetesDeployer) (map[string]string, error) {
+	properties := map[string]string{}
+	for app, deployer := range deployers {
+		for name, value := range deployer.EnvironmentVariables {
+			if strings.Contains(value, ",") && strings.Contains(value, "'") {
+				return nil, errors.Errorf(errEnvironmentValue, name, app)
+			}
+		}
+
+		prefix := "deployer." + app + ".kubernetes."
+		for key, value := range renderKubernetesDeployer(&deployer) {
+			properties[prefix+key] = value
+		}
+	}
+	return properties, nil
+}
+
+func renderKubernetesDeployer(deployer *core.KubernetesDeployer) map[string]string {
+	properties := map[string]string{}
+	set := func(key string, value *string) {
+		if value != nil {
+			properties[key] = *value
+		}
+	}
+
+	if deployer.Limits != nil {
+		set("limits.cpu", deployer.Limits.CPU)
+		set("limits.memory", deployer.Limits.Memory)
+	}
+
+	if deployer.Requests != nil {
+		set("requests.cpu", deployer.Requests.CPU)
+		set("requests.memory", deployer.Requests.Memory)
+	}
+
+	if len(deployer.EnvironmentVariables) > 0 {
+		properties["environmentVariables"] = joinPairs(deployer.EnvironmentVariables, "=", quoteEnvironmentValue)
+	}
+
+	if len(deployer.SecretRefs) > 0 {
+		properties["secretRefs"] = "[" + strings.Join(deployer.SecretRefs, ",") + "]"
+	}
+
+	if len(deployer.ConfigMapRefs) > 0 {
+		properties["configMapRefs"] = "[" + strings.Join(deployer.ConfigMapRefs, ",") + "]"
+	}
+
+	if len(deployer.PodAnnotations) > 0 {
+		properties["podAnnotations"] = joinPairs(deployer.PodAnnotations, ":", nil)
+	}
+
+	if len(deployer.DeploymentLabels) > 0 {
+		properties["deploymentLabels"] = joinPairs(deployer.DeploymentLabels, ":", nil)
+	}
+
+	if len(deployer.NodeSelector) > 0 {
+		properties["nodeSelector"] = joinPairs(deployer.NodeSelector, ":", nil)
+	}
+
+	if len(deployer.Tolerations) > 0 {
+		tolerations := make([]string, 0, len(deployer.Tolerations))
+		for _, toleration := range deployer.Tolerations {
+			tolerations = append(tolerations, renderToleration(toleration))
+		}
+		properties["tolerations"] = "[" + strings.Join(tolerations, ", ") + "]"
+	}
+
+	set("deploymentServiceAccountName", deployer.ServiceAccountName)
+	set("imagePullPolicy", deployer.ImagePullPolicy)
+	set("imagePullSecret", deployer.ImagePullSecret)
+
+	return properties
+}
+
+// renderToleration renders the toleration in the inline yaml form expected
+// by the deployer, i.e. {key: 'test', operator: 'Equal', value: 'true', effect: 'NoSchedule'}
+func renderToleration(toleration core.KubernetesToleration) string {
+	var fields []string
+	quoted := func(name string, value *string) {
+		if value != nil {
+			fields = append(fields, name+": '"+strings.ReplaceAll(*value, "'", "''")+"'")
+		}
+	}
+
+	quoted("key", toleration.Key)
+	quoted("operator", toleration.Operator)
+	quoted("value", toleration.Value)
+	quoted("effect", toleration.Effect)
+	if toleration.TolerationSeconds != nil {
+		fields = append(fields, "tolerationSeconds: "+strconv.FormatInt(*toleration.TolerationSeconds, 10))
+	}
+
+	return "{" + strings.Join(fields, ", ") + "}"
+}
+
+// quoteEnvironmentValue encloses values containing commas in single quotes,
+// so that the deployer does not split them into separate variables. The
+// deployer removes all single quotes of such values, therefore values
+// containing a single quote too are rejected before.
+func quoteEnvironmentValue(value string) string {
+	if strings.ContainsAny(value, ",") {
+		return "'" + value + "'"
+	}
+	return value
+}
+
+func joinPairs(pairs map[string]string, separator string, quote func(string) string) string {
+	keys := make([]string, 0, len(pairs))
+	for key := range pairs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	rendered := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := pairs[key]
+		if quote != nil {
+			value = quote(value)
+		}
+		rendered = append(rendered, key+separator+value)
+	}
+	return strings.Join(rendered, ",")
+}
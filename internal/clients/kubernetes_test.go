@@ -0,0 +1,119 @@
+package clients
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestKubernetesDeployerProperties(t *testing.T) {
+	cases := map[string]struct {
+		deployers map[string]core.KubernetesDeployer
+		want      map[string]string
+		wantErr   bool
+	}{
+		"Empty": {
+			deployers: nil,
+			want:      map[string]string{},
+		},
+		"Resources": {
+			deployers: map[string]core.KubernetesDeployer{
+				"log": {
+					Limits:   &core.KubernetesResources{CPU: ptr("1"), Memory: ptr("1Gi")},
+					Requests: &core.KubernetesResources{Memory: ptr("512Mi")},
+				},
+			},
+			want: map[string]string{
+				"deployer.log.kubernetes.limits.cpu":      "1",
+				"deployer.log.kubernetes.limits.memory":   "1Gi",
+				"deployer.log.kubernetes.requests.memory": "512Mi",
+			},
+		},
+		"MapsAndLists": {
+			deployers: map[string]core.KubernetesDeployer{
+				"time": {
+					EnvironmentVariables: map[string]string{"JAVA_TOOL_OPTIONS": "-Xmx512m", "HOSTS": "a,b"},
+					SecretRefs:           []string{"db", "api"},
+					ConfigMapRefs:        []string{"settings"},
+					PodAnnotations:       map[string]string{"b": "2", "a": "1"},
+					DeploymentLabels:     map[string]string{"team": "data"},
+					NodeSelector:         map[string]string{"disktype": "ssd"},
+				},
+			},
+			want: map[string]string{
+				"deployer.time.kubernetes.environmentVariables": "HOSTS='a,b',JAVA_TOOL_OPTIONS=-Xmx512m",
+				"deployer.time.kubernetes.secretRefs":           "[db,api]",
+				"deployer.time.kubernetes.configMapRefs":        "[settings]",
+				"deployer.time.kubernetes.podAnnotations":       "a:1,b:2",
+				"deployer.time.kubernetes.deploymentLabels":     "team:data",
+				"deployer.time.kubernetes.nodeSelector":         "disktype:ssd",
+			},
+		},
+		"CommaAndQuote": {
+			deployers: map[string]core.KubernetesDeployer{
+				"time": {
+					EnvironmentVariables: map[string]string{"GREETING": "it's a,b"},
+				},
+			},
+			wantErr: true,
+		},
+		"QuoteWithoutComma": {
+			deployers: map[string]core.KubernetesDeployer{
+				"time": {
+					EnvironmentVariables: map[string]string{"GREETING": "it's"},
+				},
+			},
+			want: map[string]string{
+				"deployer.time.kubernetes.environmentVariables": "GREETING=it's",
+			},
+		},
+		"Tolerations": {
+			deployers: map[string]core.KubernetesDeployer{
+				"log": {
+					Tolerations: []core.KubernetesToleration{
+						{Key: ptr("dedicated"), Operator: ptr("Equal"), Value: ptr("streams"), Effect: ptr("NoSchedule")},
+						{Operator: ptr("Exists"), Effect: ptr("NoExecute"), TolerationSeconds: ptr(int64(60))},
+					},
+				},
+			},
+			want: map[string]string{
+				"deployer.log.kubernetes.tolerations": "[{key: 'dedicated', operator: 'Equal', value: 'streams', effect: 'NoSchedule'}, {operator: 'Exists', effect: 'NoExecute', tolerationSeconds: 60}]",
+			},
+		},
+		"PodSettingsPerApp": {
+			deployers: map[string]core.KubernetesDeployer{
+				"time": {ServiceAccountName: ptr("streams")},
+				"log":  {ImagePullPolicy: ptr("Always"), ImagePullSecret: ptr("registry")},
+			},
+			want: map[string]string{
+				"deployer.time.kubernetes.deploymentServiceAccountName": "streams",
+				"deployer.log.kubernetes.imagePullPolicy":               "Always",
+				"deployer.log.kubernetes.imagePullSecret":               "registry",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := KubernetesDeployerProperties(tc.deployers)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("KubernetesDeployerProperties(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package clients
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Platform is a Skipper deployer platform or a task launcher platform of the
+// Data Flow server
+type Platform struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// PlatformError is returned, if a platform is not known to the server
+type PlatformError struct {
+	Name      string
+	Available []string
+}
+
+func (e *PlatformError) Error() string {
+	return fmt.Sprintf("platform '%s' does not exist, available platforms: %s", e.Name, strings.Join(e.Available, ", "))
+}
+
+// FindPlatform returns the platform with the name or a *PlatformError
+func FindPlatform(platforms []Platform, name string) (*Platform, error) {
+	available := make([]string, 0, len(platforms))
+	for i := range platforms {
+		if platforms[i].Name == name {
+			return &platforms[i], nil
+		}
+		available = append(available, platforms[i].Name)
+	}
+	return nil, &PlatformError{Name: name, Available: available}
+}
@@ -0,0 +1,52 @@
+package clients
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFindPlatform(t *testing.T) {
+	platforms := []Platform{
+		{Name: "default", Type: "kubernetes"},
+		{Name: "team-a", Type: "kubernetes"},
+		{Name: "local", Type: "local"},
+	}
+
+	cases := map[string]struct {
+		name    string
+		want    string
+		wantErr string
+	}{
+		"Default": {
+			name: "default",
+			want: "kubernetes",
+		},
+		"Local": {
+			name: "local",
+			want: "local",
+		},
+		"Unknown": {
+			name:    "team-b",
+			wantErr: "platform 'team-b' does not exist, available platforms: default, team-a, local",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := FindPlatform(platforms, tc.name)
+			if tc.wantErr != "" {
+				var platformErr *PlatformError
+				if !errors.As(err, &platformErr) || err.Error() != tc.wantErr {
+					t.Fatalf("expected '%s', got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Type != tc.want {
+				t.Errorf("expected type '%s', got '%s'", tc.want, got.Type)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package clients
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+)
+
+const (
+	errNoKubeClient    = "no kubernetes client to resolve property '%s'"
+	errGetSecret       = "failed to get secret for property '%s'"
+	errGetConfigMap    = "failed to get configmap for property '%s'"
+	errMissingKey      = "key '%s' of %s '%s/%s' for property '%s' does not exist"
+	errNoValueProperty = "property '%s' has neither value nor valueFrom"
+)
+
+// ResolveProperties returns the properties with the values of all referenced
+// Secrets and ConfigMaps. The values must never be written to the status.
+func ResolveProperties(ctx context.Context, kube client.Reader, properties []core.Property) (map[string]string, error) {
+	resolved := make(map[string]string, len(properties))
+	for _, property := range properties {
+		value, err := resolveProperty(ctx, kube, property)
+		if err != nil {
+			return nil, err
+		}
+		resolved[property.Key] = value
+	}
+	return resolved, nil
+}
+
+func resolveProperty(ctx context.Context, kube client.Reader, property core.Property) (string, error) {
+	if property.Value != nil {
+		return *property.Value, nil
+	}
+
+	if property.ValueFrom == nil {
+		return "", errors.Errorf(errNoValueProperty, property.Key)
+	}
+
+	if kube == nil {
+		return "", errors.Errorf(errNoKubeClient, property.Key)
+	}
+
+	if ref := property.ValueFrom.SecretKeyRef; ref != nil {
+		secret := &corev1.Secret{}
+		err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret)
+		if err != nil {
+			return "", errors.Wrapf(err, errGetSecret, property.Key)
+		}
+
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			return "", errors.Errorf(errMissingKey, ref.Key, "secret", ref.Namespace, ref.Name, property.Key)
+		}
+		return string(value), nil
+	}
+
+	if ref := property.ValueFrom.ConfigMapKeyRef; ref != nil {
+		configMap := &corev1.ConfigMap{}
+		err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, configMap)
+		if err != nil {
+			return "", errors.Wrapf(err, errGetConfigMap, property.Key)
+		}
+
+		value, ok := configMap.Data[ref.Key]
+		if !ok {
+			return "", errors.Errorf(errMissingKey, ref.Key, "configmap", ref.Namespace, ref.Name, property.Key)
+		}
+		return value, nil
+	}
+
+	return "", errors.Errorf(errNoValueProperty, property.Key)
+}
+
+// hashKey keys the hashes of the resolved properties, see SetHashKey
+var hashKey []byte
+
+// SetHashKey sets the key of the hashes of the resolved properties. The key
+// is held by the provider, so that the values of referenced Secrets can not
+// be brute-forced from the hashes by everyone, who can read the resource.
+func SetHashKey(key []byte) {
+	hashKey = key
+}
+
+// HashProperties returns a keyed hash of the resolved properties, so that
+// changes of referenced Secrets and ConfigMaps can be detected without
+// keeping their values. The salt (i.e. the UID of the resource) prevents
+// comparing hashes across resources.
+func HashProperties(salt string, properties map[string]string) string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := hmac.New(sha256.New, hashKey)
+	hash.Write([]byte(salt))
+	for _, key := range keys {
+		hash.Write([]byte{0})
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+		hash.Write([]byte(properties[key]))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// ReferencesObject reports whether one of the properties reads its value from
+// the Secret or ConfigMap
+func ReferencesObject(properties []core.Property, obj client.Object) bool {
+	for _, property := range properties {
+		if property.ValueFrom == nil {
+			continue
+		}
+
+		switch obj.(type) {
+		case *corev1.Secret:
+			ref := property.ValueFrom.SecretKeyRef
+			if ref != nil && ref.Name == obj.GetName() && ref.Namespace == obj.GetNamespace() {
+				return true
+			}
+		case *corev1.ConfigMap:
+			ref := property.ValueFrom.ConfigMapKeyRef
+			if ref != nil && ref.Name == obj.GetName() && ref.Namespace == obj.GetNamespace() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// JoinProperties renders the properties as comma separated key=value pairs
+// sorted by key, as expected by the schedule and launch endpoints. Values
+// containing commas are quoted, so that the server does not split them.
+func JoinProperties(properties map[string]string) string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+quotePropertyValue(properties[key]))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// JoinArguments renders the arguments separated by spaces, as expected by
+// the schedule and launch endpoints. Arguments containing whitespace are
+// quoted, so that the server does not split them.
+func JoinArguments(arguments []string) string {
+	quoted := make([]string, 0, len(arguments))
+	for _, argument := range arguments {
+		quoted = append(quoted, quoteArgument(argument))
+	}
+	return strings.Join(quoted, " ")
+}
+
+// The server splits properties at commas and only continues the previous
+// value with parts, that do not look like a property themselves. Which keys
+// look like a property depends on the server version, therefore every value
+// containing a comma is quoted.
+func quotePropertyValue(value string) string {
+	if !strings.Contains(value, ",") || isQuoted(value) {
+		return value
+	}
+	return `"` + value + `"`
+}
+
+func quoteArgument(argument string) string {
+	if !strings.ContainsAny(argument, " \t\n") || isQuoted(argument) {
+		return argument
+	}
+
+	// --key="value with spaces" is passed to the task as --key=value with spaces
+	key, value, found := strings.Cut(argument, "=")
+	if found && !strings.ContainsAny(key, " \t\n") {
+		if isQuoted(value) {
+			return argument
+		}
+		return key + `="` + value + `"`
+	}
+	return `"` + argument + `"`
+}
+
+func isQuoted(value string) bool {
+	return len(value) > 1 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`)
+}
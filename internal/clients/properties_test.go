@@ -0,0 +1,250 @@
+package clients
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+)
+
+func secretProperty(key string, name string, secretKey string) core.Property {
+	return core.Property{Key: key, ValueFrom: &core.PropertyValueSource{
+		SecretKeyRef: &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: name, Namespace: "default"},
+			Key:             secretKey,
+		},
+	}}
+}
+
+func configMapProperty(key string, name string, configMapKey string) core.Property {
+	return core.Property{Key: key, ValueFrom: &core.PropertyValueSource{
+		ConfigMapKeyRef: &core.ConfigMapKeySelector{Name: name, Namespace: "default", Key: configMapKey},
+	}}
+}
+
+func TestResolveProperties(t *testing.T) {
+	value := "INFO"
+	kube := fake.NewClientBuilder().WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+			Data:       map[string][]byte{"password": []byte("s3cr3t")},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"},
+			Data:       map[string]string{"url": "jdbc:postgresql://db/app"},
+		},
+	).Build()
+
+	cases := map[string]struct {
+		kube       client.Reader
+		properties []core.Property
+		want       map[string]string
+		wantErr    bool
+	}{
+		"Value": {
+			properties: []core.Property{{Key: "app.log.logging.level", Value: &value}},
+			want:       map[string]string{"app.log.logging.level": "INFO"},
+		},
+		"SecretAndConfigMap": {
+			kube: kube,
+			properties: []core.Property{
+				secretProperty("app.jdbc.spring.datasource.password", "db", "password"),
+				configMapProperty("app.jdbc.spring.datasource.url", "settings", "url"),
+			},
+			want: map[string]string{
+				"app.jdbc.spring.datasource.password": "s3cr3t",
+				"app.jdbc.spring.datasource.url":      "jdbc:postgresql://db/app",
+			},
+		},
+		"MissingSecret": {
+			kube:       kube,
+			properties: []core.Property{secretProperty("p", "other", "password")},
+			wantErr:    true,
+		},
+		"MissingKey": {
+			kube:       kube,
+			properties: []core.Property{secretProperty("p", "db", "username")},
+			wantErr:    true,
+		},
+		"NoKubeClient": {
+			properties: []core.Property{secretProperty("p", "db", "password")},
+			wantErr:    true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ResolveProperties(context.Background(), tc.kube, tc.properties)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ResolveProperties(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHashProperties(t *testing.T) {
+	hash := HashProperties("uid", map[string]string{"a": "1", "b": "2"})
+
+	if got := HashProperties("uid", map[string]string{"b": "2", "a": "1"}); got != hash {
+		t.Errorf("expected equal hash regardless of order")
+	}
+
+	SetHashKey([]byte("other key"))
+	defer SetHashKey(nil)
+	otherKey := HashProperties("uid", map[string]string{"a": "1", "b": "2"})
+	SetHashKey(nil)
+
+	for name, other := range map[string]string{
+		"ChangedValue": HashProperties("uid", map[string]string{"a": "1", "b": "3"}),
+		"ShiftedValue": HashProperties("uid", map[string]string{"a": "1b", "": "2"}),
+		"OtherSalt":    HashProperties("other", map[string]string{"a": "1", "b": "2"}),
+		"OtherKey":     otherKey,
+	} {
+		if other == hash {
+			t.Errorf("%s: expected different hash", name)
+		}
+	}
+}
+
+func TestReferencesObject(t *testing.T) {
+	properties := []core.Property{
+		secretProperty("a", "db", "password"),
+		configMapProperty("b", "settings", "url"),
+	}
+
+	cases := map[string]struct {
+		obj  client.Object
+		want bool
+	}{
+		"Secret": {
+			obj:  &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"}},
+			want: true,
+		},
+		"SecretInOtherNamespace": {
+			obj:  &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "other"}},
+			want: false,
+		},
+		"ConfigMap": {
+			obj:  &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"}},
+			want: true,
+		},
+		"ConfigMapWithNameOfSecret": {
+			obj:  &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"}},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := ReferencesObject(properties, tc.obj); got != tc.want {
+				t.Errorf("ReferencesObject(...): expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestJoinProperties(t *testing.T) {
+	cases := map[string]struct {
+		properties map[string]string
+		want       string
+	}{
+		"Sorted": {
+			properties: map[string]string{"b": "2", "a": "1"},
+			want:       "a=1,b=2",
+		},
+		"Commas": {
+			properties: map[string]string{"scheduler.kubernetes.jobAnnotations": "annotation1:value1,annotation2:value2"},
+			want:       `scheduler.kubernetes.jobAnnotations="annotation1:value1,annotation2:value2"`,
+		},
+		"EqualsSigns": {
+			properties: map[string]string{"app.a.query": "x=1&y=2"},
+			want:       "app.a.query=x=1&y=2",
+		},
+		"CommasAndEqualsSigns": {
+			properties: map[string]string{"app.a.filter": "x=1,y=2"},
+			want:       `app.a.filter="x=1,y=2"`,
+		},
+		"CommaFollowedByProperty": {
+			properties: map[string]string{"app.a.list": "first,app.b.c=d"},
+			want:       `app.a.list="first,app.b.c=d"`,
+		},
+		"Brackets": {
+			properties: map[string]string{"deployer.a.kubernetes.secretRefs": "[secret-a,secret-b]"},
+			want:       `deployer.a.kubernetes.secretRefs="[secret-a,secret-b]"`,
+		},
+		"BracketsWithProperty": {
+			properties: map[string]string{"deployer.a.kubernetes.volumes": "[{name: x, app.b=c}]"},
+			want:       `deployer.a.kubernetes.volumes="[{name: x, app.b=c}]"`,
+		},
+		"CommaFollowedByUnknownPrefix": {
+			properties: map[string]string{"app.a.list": "first,management.b=c"},
+			want:       `app.a.list="first,management.b=c"`,
+		},
+		"AlreadyQuoted": {
+			properties: map[string]string{"app.a.list": `"first,app.b.c=d"`},
+			want:       `app.a.list="first,app.b.c=d"`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := JoinProperties(tc.properties)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("JoinProperties(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJoinArguments(t *testing.T) {
+	cases := map[string]struct {
+		arguments []string
+		want      string
+	}{
+		"Simple": {
+			arguments: []string{"--a=1", "--b=2"},
+			want:      "--a=1 --b=2",
+		},
+		"WhitespaceInValue": {
+			arguments: []string{"--name=hello world"},
+			want:      `--name="hello world"`,
+		},
+		"WhitespaceWithoutKey": {
+			arguments: []string{"hello world"},
+			want:      `"hello world"`,
+		},
+		"CommasEqualsSignsAndBrackets": {
+			arguments: []string{"--list=[a,b]", "--filter=x=1,y=2"},
+			want:      "--list=[a,b] --filter=x=1,y=2",
+		},
+		"AlreadyQuoted": {
+			arguments: []string{`--name="hello world"`},
+			want:      `--name="hello world"`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := JoinArguments(tc.arguments)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("JoinArguments(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
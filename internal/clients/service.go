@@ -6,6 +6,7 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	client "github.com/denniskniep/spring-cloud-dataflow-sdk-go/v2/client"
+	kiota "github.com/microsoft/kiota-abstractions-go"
 	auth "github.com/microsoft/kiota-abstractions-go/authentication"
 	http "github.com/microsoft/kiota-http-go"
 )
@@ -22,6 +23,27 @@ func (s *DataFlowService) Client() *client.DataFlowClient {
 	return s.client
 }
 
+// SendJson sends the request with the json encoded body, for endpoints whose
+// generated request builders do not accept a body
+func (s *DataFlowService) SendJson(ctx context.Context, requestInfo *kiota.RequestInformation, body any) ([]byte, error) {
+	content, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	requestInfo.SetStreamContentAndContentType(content, "application/json")
+	return s.Send(ctx, requestInfo)
+}
+
+// Send sends the request and returns the response body, for endpoints whose
+// generated request builders discard it
+func (s *DataFlowService) Send(ctx context.Context, requestInfo *kiota.RequestInformation) ([]byte, error) {
+	res, err := s.client.RequestAdapter.SendPrimitive(ctx, requestInfo, "[]byte", nil)
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return res.([]byte), nil
+}
+
 func NewDataFlowService(configData []byte) (*DataFlowService, error) {
 	var conf = DataFlowServiceConfig{}
 	err := json.Unmarshal(configData, &conf)
@@ -69,6 +91,13 @@ type Service[R resource.Managed, P any, O any, C any] interface {
 	MakeCompare() *C
 }
 
+// A CompareMapper can be implemented by a Service, if spec (P) and observation (O)
+// can not be mapped to the compareable (C) by their json representation
+type CompareMapper[P any, O any, C any] interface {
+	MapSpecToCompare(param *P) (*C, error)
+	MapObservationToCompare(observed *O) (*C, error)
+}
+
 func GetJsonConfigForTests() string {
 	return `{
 		"url": "http://localhost:9393"
@@ -0,0 +1,93 @@
+package stream
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients"
+	"github.com/denniskniep/spring-cloud-dataflow-sdk-go/v2/client/models"
+)
+
+const (
+	errActuator = "failed to post to actuator endpoint '%s'"
+
+	loggersEndpoint  = "loggers/"
+	bindingsEndpoint = "bindings/"
+)
+
+// ActuatorRequest is a post to an actuator endpoint of an app instance
+type ActuatorRequest struct {
+	Endpoint string
+	Body     map[string]string
+}
+
+// ActuatorSettings flattens the settings to their actuator endpoint and value
+func ActuatorSettings(actuator *core.StreamAppActuator) map[string]string {
+	settings := map[string]string{}
+	for logger, level := range actuator.LoggerLevels {
+		settings[loggersEndpoint+logger] = level
+	}
+	for binding, state := range actuator.Bindings {
+		settings[bindingsEndpoint+binding] = state
+	}
+	return settings
+}
+
+// HashActuator returns the hash of the settings, that is recorded per
+// instance once they are applied
+func HashActuator(actuator *core.StreamAppActuator) string {
+	return clients.HashProperties("", ActuatorSettings(actuator))
+}
+
+// ActuatorRequests returns the posts, that apply the settings, sorted by
+// endpoint
+func ActuatorRequests(actuator *core.StreamAppActuator) []ActuatorRequest {
+	settings := ActuatorSettings(actuator)
+	endpoints := make([]string, 0, len(settings))
+	for endpoint := range settings {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	requests := make([]ActuatorRequest, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		var body map[string]string
+		if strings.HasPrefix(endpoint, loggersEndpoint) {
+			body = map[string]string{"configuredLevel": settings[endpoint]}
+		} else {
+			body = map[string]string{"state": settings[endpoint]}
+		}
+		requests = append(requests, ActuatorRequest{Endpoint: endpoint, Body: body})
+	}
+	return requests
+}
+
+// ApplyActuator posts the settings to the actuator of the app instance
+// through the Data Flow passthrough
+func (s *StreamService) ApplyActuator(ctx context.Context, deploymentId string, instanceId string, actuator *core.StreamAppActuator) error {
+	builder := s.Client().Runtime().Apps().ByAppId(deploymentId).Instances().ByInstanceId(instanceId).Actuator()
+
+	for _, request := range ActuatorRequests(actuator) {
+		additionalData := make(map[string]any, len(request.Body))
+		for key, value := range request.Body {
+			additionalData[key] = value
+		}
+
+		body := models.NewActuatorPostRequest_body()
+		body.SetAdditionalData(additionalData)
+
+		post := models.NewActuatorPostRequest()
+		post.SetEndpoint(&request.Endpoint)
+		post.SetBody(body)
+
+		_, err := builder.Post(ctx, post, nil)
+		if err != nil {
+			return errors.Wrapf(err, errActuator, request.Endpoint)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+)
+
+func TestActuatorRequests(t *testing.T) {
+	cases := map[string]struct {
+		actuator core.StreamAppActuator
+		want     []ActuatorRequest
+	}{
+		"Empty": {
+			actuator: core.StreamAppActuator{},
+			want:     []ActuatorRequest{},
+		},
+		"LoggersAndBindings": {
+			actuator: core.StreamAppActuator{
+				LoggerLevels: map[string]string{"org.springframework": "DEBUG", "com.example": "WARN"},
+				Bindings:     map[string]string{"input": "PAUSED"},
+			},
+			want: []ActuatorRequest{
+				{Endpoint: "bindings/input", Body: map[string]string{"state": "PAUSED"}},
+				{Endpoint: "loggers/com.example", Body: map[string]string{"configuredLevel": "WARN"}},
+				{Endpoint: "loggers/org.springframework", Body: map[string]string{"configuredLevel": "DEBUG"}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ActuatorRequests(&tc.actuator)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ActuatorRequests(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHashActuator(t *testing.T) {
+	paused := core.StreamAppActuator{Bindings: map[string]string{"input": "PAUSED"}}
+	resumed := core.StreamAppActuator{Bindings: map[string]string{"input": "RESUMED"}}
+
+	if HashActuator(&paused) == HashActuator(&resumed) {
+		t.Errorf("expected different hashes for different binding states")
+	}
+	if HashActuator(&paused) != HashActuator(&core.StreamAppActuator{Bindings: map[string]string{"input": "PAUSED"}}) {
+		t.Errorf("expected equal hashes for equal settings")
+	}
+}
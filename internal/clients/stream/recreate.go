@@ -0,0 +1,91 @@
+package stream
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients"
+	"github.com/denniskniep/provider-springclouddataflow/internal/dsl"
+)
+
+const (
+	errRecreate = "failed to recreate stream"
+	errRestored = "failed to recreate stream, the previous definition was restored"
+	errRestore  = "failed to restore previous stream definition"
+	errMasked   = "failed to recreate stream, the previous definition contains masked values and can not be restored"
+)
+
+// DefinitionChanged reports whether the definition or description on the
+// server differ from the desired ones. Both can only be changed by recreating
+// the stream.
+func (s *StreamService) DefinitionChanged(stream *core.StreamParameters, observed *core.StreamObservation) bool {
+	desired, _ := s.MapSpecToCompare(stream)
+	current, _ := s.MapObservationToCompare(observed)
+	return *desired != *current
+}
+
+// SensitiveArgumentsHash returns the hash of the sensitive app arguments of
+// the definition. The server masks their values, therefore changes are only
+// detected by comparing the hash with the one recorded when the definition
+// was created.
+func SensitiveArgumentsHash(salt string, stream *core.StreamParameters) string {
+	var arguments map[string]string
+	node, err := dsl.ParseStream(Definition(stream))
+	if err == nil {
+		arguments = node.SensitiveArguments()
+	}
+	return clients.HashProperties(salt, arguments)
+}
+
+// Recreate replaces the stream on the server, as the definition of an
+// existing stream can not be changed. The new definition is checked first,
+// then the stream is deleted, which undeploys it, and created again, which
+// deploys it with the already resolved properties if requested. If creating
+// fails, the previous definition is restored with its previous deployment
+// state.
+func (s *StreamService) Recreate(ctx context.Context, stream *core.StreamParameters, observed *core.StreamObservation, properties map[string]string) error {
+	err := s.Preflight(ctx, stream)
+	if err != nil {
+		return err
+	}
+
+	err = s.Delete(ctx, stream)
+	if err != nil {
+		return errors.Wrap(err, errRecreate)
+	}
+
+	err = s.CreateWithProperties(ctx, stream, properties)
+	if err == nil {
+		return nil
+	}
+
+	previous := PreviousDefinition(stream, observed)
+	if previous == nil {
+		return errors.Wrap(err, errMasked)
+	}
+
+	restoreErr := s.CreateWithProperties(ctx, previous, properties)
+	if restoreErr != nil {
+		return errors.Wrapf(restoreErr, "%s (%s)", errRestore, err)
+	}
+	return errors.Wrap(err, errRestored)
+}
+
+// PreviousDefinition returns the parameters of the stream as it exists on
+// the server, so that it can be restored. It returns nil, if the server
+// masked sensitive values of the definition.
+func PreviousDefinition(stream *core.StreamParameters, observed *core.StreamObservation) *core.StreamParameters {
+	if strings.Contains(observed.Definition, dsl.MaskedValue) {
+		return nil
+	}
+
+	previous := stream.DeepCopy()
+	previous.Description = observed.Description
+	previous.Definition = observed.Definition
+	previous.Topology = nil
+	previous.Deploy = observed.Status != "" && observed.Status != StatusUndeployed
+	return previous
+}
@@ -0,0 +1,27 @@
+package stream
+
+import (
+	"testing"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+)
+
+func TestSensitiveArgumentsHash(t *testing.T) {
+	applied := &core.StreamParameters{Definition: "time | rabbit --routing-key=a --exchange=x"}
+	changed := &core.StreamParameters{Definition: "time | rabbit --routing-key=b --exchange=x"}
+	other := &core.StreamParameters{Definition: "time | rabbit --routing-key=a --exchange=y"}
+
+	srv := &StreamService{}
+	desired, _ := srv.MapSpecToCompare(changed)
+	current, _ := srv.MapSpecToCompare(applied)
+	if *desired != *current {
+		t.Errorf("expected equal masked definitions")
+	}
+
+	if SensitiveArgumentsHash("uid", applied) == SensitiveArgumentsHash("uid", changed) {
+		t.Errorf("expected different hashes for changed sensitive arguments")
+	}
+	if SensitiveArgumentsHash("uid", applied) != SensitiveArgumentsHash("uid", other) {
+		t.Errorf("expected equal hashes for changed insensitive arguments")
+	}
+}
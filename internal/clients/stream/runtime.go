@@ -0,0 +1,143 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	kiota "github.com/microsoft/kiota-abstractions-go"
+)
+
+const (
+	errRuntime = "failed to get runtime status"
+	errLogs    = "failed to get stream logs"
+
+	// StatusFailed is the status of streams, whose apps all failed
+	StatusFailed = "failed"
+
+	// StatusPartial is the status of streams, whose apps are partially deployed
+	StatusPartial = "partial"
+
+	appStateDeployed  = "deployed"
+	appStateDeploying = "deploying"
+
+	truncatedLogPrefix = "...\n"
+)
+
+type StreamRuntimeResponse struct {
+	Embedded struct {
+		Streams []StreamRuntimeStatus `json:"streamStatusResourceList"`
+	} `json:"_embedded"`
+}
+
+type StreamRuntimeStatus struct {
+	Name         string `json:"name"`
+	Applications struct {
+		Embedded struct {
+			Apps []AppRuntimeStatus `json:"appStatusResourceList"`
+		} `json:"_embedded"`
+	} `json:"applications"`
+}
+
+// AppRuntimeStatus is the state of a deployed app of a stream
+type AppRuntimeStatus struct {
+	DeploymentId string `json:"deploymentId"`
+	Name         string `json:"name"`
+	State        string `json:"state"`
+	Instances    struct {
+		Embedded struct {
+			Instances []AppInstanceRuntimeStatus `json:"appInstanceStatusResourceList"`
+		} `json:"_embedded"`
+	} `json:"instances"`
+}
+
+// AppInstanceRuntimeStatus is the state of a single instance of an app
+type AppInstanceRuntimeStatus struct {
+	InstanceId string            `json:"instanceId"`
+	State      string            `json:"state"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+type StreamLogsResponse struct {
+	Logs map[string]string `json:"logs"`
+}
+
+// Failing reports whether the app is neither deployed nor being deployed
+func (a *AppRuntimeStatus) Failing() bool {
+	return a.State != appStateDeployed && a.State != appStateDeploying
+}
+
+// RuntimeApps returns the runtime state of all deployed apps of the stream
+func (s *StreamService) RuntimeApps(ctx context.Context, name string) ([]AppRuntimeStatus, error) {
+	result, err := s.Client().Runtime().Streams().ByStreamNames(name).Get(ctx, nil)
+
+	var apiError *kiota.ApiError
+	if errors.As(err, &apiError) && apiError.ResponseStatusCode == 404 {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, errRuntime)
+	}
+
+	var response = StreamRuntimeResponse{}
+	err = json.Unmarshal(result, &response)
+	if err != nil {
+		return nil, errors.Wrap(err, errRuntime)
+	}
+
+	for _, stream := range response.Embedded.Streams {
+		if stream.Name == name {
+			return stream.Applications.Embedded.Apps, nil
+		}
+	}
+	return nil, nil
+}
+
+// Logs returns the logs of all deployed apps of the stream keyed by
+// deployment id
+func (s *StreamService) Logs(ctx context.Context, name string) (map[string]string, error) {
+	result, err := s.Client().Streams().Logs().ByStreamName(name).Get(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errLogs)
+	}
+
+	var response = StreamLogsResponse{}
+	err = json.Unmarshal(result, &response)
+	if err != nil {
+		return nil, errors.Wrap(err, errLogs)
+	}
+
+	return response.Logs, nil
+}
+
+// TailLog returns at most the last maxLines lines of the log, that fit into
+// maxBytes. Truncated logs are prefixed with an ellipsis.
+func TailLog(log string, maxLines int, maxBytes int) string {
+	log = strings.TrimRight(log, "\n")
+	truncated := false
+
+	lines := strings.Split(log, "\n")
+	if len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+		truncated = true
+	}
+	tail := strings.Join(lines, "\n")
+
+	if len(tail) > maxBytes-len(truncatedLogPrefix) {
+		tail = tail[len(tail)-maxBytes+len(truncatedLogPrefix):]
+		// Do not start within a multi byte character
+		for len(tail) > 0 && !utf8.RuneStart(tail[0]) {
+			tail = tail[1:]
+		}
+		truncated = true
+	}
+
+	if truncated {
+		return truncatedLogPrefix + tail
+	}
+	return tail
+}
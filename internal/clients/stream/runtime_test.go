@@ -0,0 +1,52 @@
+package stream
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTailLog(t *testing.T) {
+	cases := map[string]struct {
+		log      string
+		maxLines int
+		maxBytes int
+		want     string
+	}{
+		"Short": {
+			log:      "started\nfailed\n",
+			maxLines: 10,
+			maxBytes: 100,
+			want:     "started\nfailed",
+		},
+		"Lines": {
+			log:      "a\nb\nc\nd",
+			maxLines: 2,
+			maxBytes: 100,
+			want:     "...\nc\nd",
+		},
+		"Bytes": {
+			log:      strings.Repeat("x", 20) + "\nerror",
+			maxLines: 10,
+			maxBytes: 14,
+			want:     "...\nxxxx\nerror",
+		},
+		"MultiByteCharacter": {
+			log:      "äöü",
+			maxLines: 10,
+			maxBytes: 7,
+			want:     "...\nü",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := TailLog(tc.log, tc.maxLines, tc.maxBytes)
+			if got != tc.want {
+				t.Errorf("expected '%s', got '%s'", tc.want, got)
+			}
+			if len(got) > tc.maxBytes {
+				t.Errorf("expected at most %d bytes, got %d", tc.maxBytes, len(got))
+			}
+		})
+	}
+}
@@ -3,13 +3,17 @@ package stream
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
 	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
 	"github.com/denniskniep/provider-springclouddataflow/internal/clients"
+	"github.com/denniskniep/provider-springclouddataflow/internal/dsl"
+	"github.com/denniskniep/spring-cloud-dataflow-sdk-go/v2/client/models"
 	"github.com/denniskniep/spring-cloud-dataflow-sdk-go/v2/client/streams"
 	kiota "github.com/microsoft/kiota-abstractions-go"
 )
@@ -17,13 +21,32 @@ import (
 const (
 	errConnecting = "failed to connect"
 	errNotStream  = "managed resource is not a Stream custom resource"
+	errValidate   = "failed to validate stream"
+	errCleanup    = "failed to delete stream definition after failed create"
+	errTapped     = "stream '%s' is still tapped by %s"
+	errDeploy     = "failed to deploy stream"
+	errProperties = "failed to resolve deployment properties"
+	errPlatforms  = "failed to list platforms"
+	errHistory    = "failed to get deployment history"
+	errUndeploy   = "failed to undeploy stream"
+
+	errAppVersions = "failed to get default app versions"
+
+	// StatusUndeployed is the status of streams without deployment
+	StatusUndeployed = "undeployed"
+
+	// PlatformNameProperty selects the Skipper platform at deploy time
+	PlatformNameProperty = "spring.cloud.dataflow.skipper.platformName"
 )
 
 type StreamService struct {
 	clients.DataFlowService
+	kube client.Reader
 }
 
-func NewStreamService(configData []byte) (clients.Service[*v1alpha1.Stream, v1alpha1.StreamParameters, v1alpha1.StreamObservation, StreamCompare], error) {
+// NewStreamService creates the service, kube is used to resolve deployment
+// properties from Secrets and ConfigMaps
+func NewStreamService(configData []byte, kube client.Reader) (*StreamService, error) {
 	dataFlowService, err := clients.NewDataFlowService(configData)
 
 	if err != nil {
@@ -32,6 +55,7 @@ func NewStreamService(configData []byte) (clients.Service[*v1alpha1.Stream, v1al
 
 	return &StreamService{
 		*dataFlowService,
+		kube,
 	}, nil
 }
 
@@ -50,6 +74,26 @@ type StreamDescribeResponse struct {
 	StatusDescription string `json:"statusDescription"`
 }
 
+// StreamRelease is a Skipper release of a deployed stream
+type StreamRelease struct {
+	Name         string `json:"name"`
+	Version      int    `json:"version"`
+	PlatformName string `json:"platformName"`
+}
+
+// AppRegistrationResponse is the registration of the default version of an app
+type AppRegistrationResponse struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Version string `json:"version"`
+}
+
+type StreamRelatedResponse struct {
+	Embedded struct {
+		StreamDefinitions []StreamDescribeResponse `json:"streamDefinitionResourceList"`
+	} `json:"_embedded"`
+}
+
 func (s *StreamService) GetSpec(app *core.Stream) *core.StreamParameters {
 	return &app.Spec.ForProvider
 }
@@ -59,7 +103,21 @@ func (s *StreamService) GetStatus(app *core.Stream) *core.StreamObservation {
 }
 
 func (s *StreamService) SetStatus(app *core.Stream, status *core.StreamObservation) {
+	// The server reports neither the applied hashes and versions nor the
+	// collected logs and actuator results. The app statuses and the platform
+	// are described again, once the definition or the deployment changed.
+	recorded := app.Status.AtProvider
+	if status.Definition == recorded.Definition && status.Status == recorded.Status {
+		status.AppStatuses = recorded.AppStatuses
+		status.Platform = recorded.Platform
+	}
 	app.Status.AtProvider = *status
+	app.Status.AtProvider.DeploymentPropertiesHash = recorded.DeploymentPropertiesHash
+	app.Status.AtProvider.SensitiveArgumentsHash = recorded.SensitiveArgumentsHash
+	app.Status.AtProvider.AppVersions = recorded.AppVersions
+	app.Status.AtProvider.FailedAppLogs = recorded.FailedAppLogs
+	app.Status.AtProvider.FailedAppLogsTime = recorded.FailedAppLogsTime
+	app.Status.AtProvider.ActuatorInstances = recorded.ActuatorInstances
 }
 
 func (s *StreamService) CreateUniqueIdentifier(spec *core.StreamParameters, status *core.StreamObservation) (*string, error) {
@@ -68,12 +126,26 @@ func (s *StreamService) CreateUniqueIdentifier(spec *core.StreamParameters, stat
 }
 
 func (s *StreamService) Create(ctx context.Context, stream *core.StreamParameters) error {
+	properties, err := s.DeploymentProperties(ctx, stream)
+	if err != nil {
+		return err
+	}
+	return s.CreateWithProperties(ctx, stream, properties)
+}
+
+// CreateWithProperties creates the stream and deploys it with the already
+// resolved deployment properties, if requested
+func (s *StreamService) CreateWithProperties(ctx context.Context, stream *core.StreamParameters, properties map[string]string) error {
+	// The definition is always created undeployed, so that it can be
+	// validated by the server before anything gets deployed
+	deploy := false
+	definition := Definition(stream)
 	err := s.Client().Streams().Definitions().Post(ctx, &streams.DefinitionsRequestBuilderPostRequestConfiguration{
 		QueryParameters: &streams.DefinitionsRequestBuilderPostQueryParameters{
 			Name:        &stream.Name,
 			Description: &stream.Description,
-			Definition:  &stream.Definition,
-			Deploy:      &stream.Deploy,
+			Definition:  &definition,
+			Deploy:      &deploy,
 		},
 	})
 
@@ -81,11 +153,293 @@ func (s *StreamService) Create(ctx context.Context, stream *core.StreamParameter
 		return err
 	}
 
+	validation, err := s.Validate(ctx, stream.Name)
+	if err == nil && !validation.IsValid() {
+		err = &clients.ValidationError{
+			Name:        stream.Name,
+			AppStatuses: validation.AppsStatuses,
+		}
+	}
+
+	if err == nil && stream.Deploy {
+		err = s.Deploy(ctx, stream, properties)
+	}
+
+	if err != nil {
+		// Never leave a half-valid or undeployed definition behind
+		deleteErr := s.Delete(ctx, stream)
+		if deleteErr != nil {
+			return errors.Wrapf(deleteErr, "%s (%s)", errCleanup, err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Preflight validates the definition before it is created, by parsing it
+// and checking that all of its apps are registered
+func (s *StreamService) Preflight(ctx context.Context, stream *core.StreamParameters) error {
+	node, err := dsl.ParseStream(Definition(stream))
+	if err != nil {
+		return &clients.ValidationError{Name: stream.Name, Cause: err}
+	}
+
+	if stream.Deploy {
+		err = s.ValidatePlatform(ctx, stream.Platform)
+		var platformErr *clients.PlatformError
+		if errors.As(err, &platformErr) {
+			return &clients.ValidationError{Name: stream.Name, Cause: err}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	appStatuses := map[string]string{}
+	for i, app := range node.Apps {
+		appType := appType(node, i)
+
+		_, err := s.Client().Apps().ByType(appType).ByName(app.Name).Get(ctx, nil)
+
+		var apiError *kiota.ApiError
+		if errors.As(err, &apiError) && apiError.ResponseStatusCode == 404 {
+			appStatuses[appType+":"+app.Name] = clients.AppStatusUnregistered
+			continue
+		}
+
+		if err != nil {
+			return errors.Wrap(err, errValidate)
+		}
+
+		appStatuses[appType+":"+app.Name] = clients.AppStatusValid
+	}
+
+	if !clients.AppStatusesValid(appStatuses) {
+		return &clients.ValidationError{Name: stream.Name, AppStatuses: appStatuses}
+	}
+
 	return nil
 }
 
-func (s *StreamService) Update(ctx context.Context, app *core.StreamParameters) error {
-	return errors.New("Update of Stream not implemented - all properties are immutable!")
+// appType returns the type, the app at the given index must be registered with
+func appType(node *dsl.StreamNode, index int) string {
+	switch {
+	case node.Unbound:
+		return "app"
+	case index == 0 && node.Source == nil:
+		return "source"
+	case index == len(node.Apps)-1 && node.Sink == nil:
+		return "sink"
+	default:
+		return "processor"
+	}
+}
+
+func (s *StreamService) Validate(ctx context.Context, name string) (*clients.ValidationResponse, error) {
+	result, err := s.Client().Streams().Validation().ByName(name).Get(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errValidate)
+	}
+
+	var response = clients.ValidationResponse{}
+	err = json.Unmarshal(result, &response)
+	if err != nil {
+		return nil, errors.Wrap(err, errValidate)
+	}
+
+	return &response, nil
+}
+
+// DeploymentProperties returns the deployment properties with the values of
+// all referenced Secrets and ConfigMaps and the rendered Kubernetes deployer
+// settings
+func (s *StreamService) DeploymentProperties(ctx context.Context, stream *core.StreamParameters) (map[string]string, error) {
+	resolved, err := clients.ResolveProperties(ctx, s.kube, stream.DeploymentProperties)
+	if err != nil {
+		return nil, errors.Wrap(err, errProperties)
+	}
+
+	properties, err := clients.KubernetesDeployerProperties(stream.KubernetesDeployer)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range resolved {
+		properties[key] = value
+	}
+	return properties, nil
+}
+
+// Deploy deploys the stream with the already resolved deployment properties
+func (s *StreamService) Deploy(ctx context.Context, stream *core.StreamParameters, properties map[string]string) error {
+	deployProperties := make(map[string]string, len(properties)+1)
+	for key, value := range properties {
+		deployProperties[key] = value
+	}
+	if stream.Platform != "" {
+		deployProperties[PlatformNameProperty] = stream.Platform
+	}
+
+	// The generated request builder does not accept the properties as body
+	requestInfo, err := s.Client().Streams().Deployments().ByName(stream.Name).ToPostRequestInformation(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, errDeploy)
+	}
+
+	_, err = s.SendJson(ctx, requestInfo, deployProperties)
+	if err != nil {
+		return errors.Wrap(err, errDeploy)
+	}
+
+	return nil
+}
+
+// Undeploy undeploys the stream, but keeps its definition
+func (s *StreamService) Undeploy(ctx context.Context, stream *core.StreamParameters) error {
+	_, err := s.Client().Streams().Deployments().ByName(stream.Name).Delete(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, errUndeploy)
+	}
+	return nil
+}
+
+// Redeploy deploys the stream again with the already resolved deployment
+// properties, i.e. to move it to another platform, which is not possible
+// with a Skipper update
+func (s *StreamService) Redeploy(ctx context.Context, stream *core.StreamParameters, properties map[string]string) error {
+	err := s.Undeploy(ctx, stream)
+	if err != nil {
+		return err
+	}
+	return s.Deploy(ctx, stream, properties)
+}
+
+// Platforms returns the platforms Skipper can deploy to
+func (s *StreamService) Platforms(ctx context.Context) ([]clients.Platform, error) {
+	result, err := s.Client().Streams().Deployments().Platform().List().Get(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errPlatforms)
+	}
+
+	var platforms []clients.Platform
+	err = json.Unmarshal(result, &platforms)
+	if err != nil {
+		return nil, errors.Wrap(err, errPlatforms)
+	}
+
+	return platforms, nil
+}
+
+// ValidatePlatform returns a *clients.PlatformError, if Skipper does not
+// know the platform
+func (s *StreamService) ValidatePlatform(ctx context.Context, platform string) error {
+	platforms, err := s.Platforms(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = clients.FindPlatform(platforms, platform)
+	return err
+}
+
+// DeployedPlatform returns the platform of the latest Skipper release
+func (s *StreamService) DeployedPlatform(ctx context.Context, name string) (string, error) {
+	result, err := s.Client().Streams().Deployments().History().ByName(name).Get(ctx, nil)
+	if err != nil {
+		return "", errors.Wrap(err, errHistory)
+	}
+
+	var releases []StreamRelease
+	err = json.Unmarshal(result, &releases)
+	if err != nil {
+		return "", errors.Wrap(err, errHistory)
+	}
+
+	var latest *StreamRelease
+	for i := range releases {
+		if latest == nil || releases[i].Version > latest.Version {
+			latest = &releases[i]
+		}
+	}
+
+	if latest == nil {
+		return "", nil
+	}
+	return latest.PlatformName, nil
+}
+
+// VersionProperty returns the property, that selects the version of an app
+// on a Skipper update
+func VersionProperty(app string) string {
+	return "version." + app
+}
+
+// DefaultAppVersions returns the registered default version of all apps of
+// the stream keyed by app label or name
+func (s *StreamService) DefaultAppVersions(ctx context.Context, stream *core.StreamParameters) (map[string]string, error) {
+	node, err := dsl.ParseStream(Definition(stream))
+	if err != nil {
+		return nil, errors.Wrap(err, errAppVersions)
+	}
+
+	versions := make(map[string]string, len(node.Apps))
+	for i, app := range node.Apps {
+		result, err := s.Client().Apps().ByType(appType(node, i)).ByName(app.Name).Get(ctx, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, errAppVersions)
+		}
+
+		var registration = AppRegistrationResponse{}
+		err = json.Unmarshal(result, &registration)
+		if err != nil {
+			return nil, errors.Wrap(err, errAppVersions)
+		}
+
+		versions[app.LabelOrName()] = registration.Version
+	}
+
+	return versions, nil
+}
+
+// Update applies the deployment properties to the deployed stream. Changed
+// definitions are recreated by the controller.
+func (s *StreamService) Update(ctx context.Context, stream *core.StreamParameters) error {
+	properties, err := s.DeploymentProperties(ctx, stream)
+	if err != nil {
+		return err
+	}
+	return s.UpdateDeployment(ctx, stream, properties)
+}
+
+// UpdateDeployment upgrades the deployed stream with the resolved properties
+// using Skipper. Properties removed from the spec keep their last value.
+func (s *StreamService) UpdateDeployment(ctx context.Context, stream *core.StreamParameters, properties map[string]string) error {
+	observed, err := s.Describe(ctx, stream)
+	if err != nil {
+		return err
+	}
+
+	if observed == nil || observed.Status == StatusUndeployed {
+		return nil
+	}
+
+	packageIdentifier := models.NewPackageIdentifier()
+	packageIdentifier.SetPackageName(&stream.Name)
+
+	updateProperties := models.NewUpdateStreamRequest_updateProperties()
+	additionalData := make(map[string]any, len(properties))
+	for key, value := range properties {
+		additionalData[key] = value
+	}
+	updateProperties.SetAdditionalData(additionalData)
+
+	body := models.NewUpdateStreamRequest()
+	body.SetReleaseName(&stream.Name)
+	body.SetPackageIdentifier(packageIdentifier)
+	body.SetUpdateProperties(updateProperties)
+
+	_, err = s.Client().Streams().Deployments().Update().ByName(stream.Name).Post(ctx, body, nil)
+	return err
 }
 
 func (s *StreamService) Describe(ctx context.Context, stream *core.StreamParameters) (*core.StreamObservation, error) {
@@ -114,11 +468,48 @@ func (s *StreamService) Describe(ctx context.Context, stream *core.StreamParamet
 		StatusDescription: response.StatusDescription,
 	}
 
+	node, err := dsl.ParseStream(response.DslText)
+	if err == nil {
+		observed.TappedStream, _ = node.TappedStream()
+		observed.NamedDestinations = node.Destinations()
+	}
+
 	return &observed, nil
 }
 
+// DescribeDeployment adds the validation of the apps and the platform of the
+// deployment to the observation. Both only change with the definition or the
+// deployment, therefore they are described separately.
+func (s *StreamService) DescribeDeployment(ctx context.Context, observed *core.StreamObservation) error {
+	validation, err := s.Validate(ctx, observed.Name)
+	if err != nil {
+		return err
+	}
+
+	platform := ""
+	if observed.Status != StatusUndeployed {
+		platform, err = s.DeployedPlatform(ctx, observed.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	observed.AppStatuses = validation.AppsStatuses
+	observed.Platform = platform
+	return nil
+}
+
 func (s *StreamService) Delete(ctx context.Context, stream *core.StreamParameters) error {
-	_, err := s.Client().Streams().Definitions().ByName(stream.Name).Delete(ctx, nil)
+	taps, err := s.TappingStreams(ctx, stream.Name)
+	if err != nil {
+		return err
+	}
+
+	if len(taps) > 0 {
+		return errors.Errorf(errTapped, stream.Name, strings.Join(taps, ", "))
+	}
+
+	_, err = s.Client().Streams().Definitions().ByName(stream.Name).Delete(ctx, nil)
 
 	var apiError *kiota.ApiError
 	if errors.As(err, &apiError) && apiError.ResponseStatusCode == 404 {
@@ -132,6 +523,54 @@ func (s *StreamService) Delete(ctx context.Context, stream *core.StreamParameter
 	return nil
 }
 
+// TappingStreams returns the names of all streams, that tap the given stream
+func (s *StreamService) TappingStreams(ctx context.Context, name string) ([]string, error) {
+	result, err := s.Client().Streams().Definitions().ByName(name).Related().Get(ctx, nil)
+
+	var apiError *kiota.ApiError
+	if errors.As(err, &apiError) && apiError.ResponseStatusCode == 404 {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var response = StreamRelatedResponse{}
+	err = json.Unmarshal(result, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var taps []string
+	for _, related := range response.Embedded.StreamDefinitions {
+		// The related streams contain the stream itself
+		if related.Name != name {
+			taps = append(taps, related.Name)
+		}
+	}
+
+	return taps, nil
+}
+
+// Definitions are compared in their canonical form, because the server
+// does not return the DSL exactly as it was posted
+func (s *StreamService) MapSpecToCompare(stream *core.StreamParameters) (*StreamCompare, error) {
+	return &StreamCompare{
+		Name:        stream.Name,
+		Description: stream.Description,
+		Definition:  canonical(Definition(stream)),
+	}, nil
+}
+
+func (s *StreamService) MapObservationToCompare(observed *core.StreamObservation) (*StreamCompare, error) {
+	return &StreamCompare{
+		Name:        observed.Name,
+		Description: observed.Description,
+		Definition:  canonical(observed.Definition),
+	}, nil
+}
+
 func (s *StreamService) MakeCompare() *StreamCompare {
 	return &StreamCompare{}
 }
@@ -139,7 +578,7 @@ func (s *StreamService) MakeCompare() *StreamCompare {
 func TestNewStreamService(t *testing.T) clients.Service[*v1alpha1.Stream, v1alpha1.StreamParameters, v1alpha1.StreamObservation, StreamCompare] {
 	jsonConfig := clients.GetJsonConfigForTests()
 
-	srv, err := NewStreamService([]byte(jsonConfig))
+	srv, err := NewStreamService([]byte(jsonConfig), nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -153,5 +592,6 @@ func TestMakeDefaultStream(name string, description string, definition string, d
 		Description: description,
 		Definition:  definition,
 		Deploy:      deploy,
+		Platform:    "default",
 	}
 }
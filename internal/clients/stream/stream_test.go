@@ -1,6 +1,7 @@
 package stream
 
 import (
+	"context"
 	"testing"
 
 	"github.com/denniskniep/provider-springclouddataflow/internal/clients/application"
@@ -26,3 +27,32 @@ func TestCreateStream(t *testing.T) {
 	controllersdk.TestDelete(t, srvApp, sourceApp)
 	controllersdk.TestDelete(t, srvApp, sinkApp)
 }
+
+func TestDeleteTappedStream(t *testing.T) {
+	controllersdk.SkipIfIsShort(t)
+
+	srvApp := application.TestNewApplicationService(t)
+	srvStream := TestNewStreamService(t)
+
+	sourceApp := application.TestMakeDefaultApplication("source", "Test022", "v1.0.0")
+	controllersdk.TestCreateAndAssert(t, srvApp, sourceApp)
+
+	sinkApp := application.TestMakeDefaultApplication("sink", "Test023", "v1.0.0")
+	controllersdk.TestCreateAndAssert(t, srvApp, sinkApp)
+
+	mainStream := TestMakeDefaultStream("MyStream02", "MyDesc", "Test022 | Test023", false)
+	controllersdk.TestCreateAndAssert(t, srvStream, mainStream)
+
+	tapStream := TestMakeDefaultStream("MyStream03", "MyDesc", ":MyStream02.Test022 > Test023", false)
+	controllersdk.TestCreateAndAssert(t, srvStream, tapStream)
+
+	err := srvStream.Delete(context.Background(), mainStream)
+	if err == nil {
+		t.Fatal("Tapped stream was deleted")
+	}
+
+	controllersdk.TestDelete(t, srvStream, tapStream)
+	controllersdk.TestDelete(t, srvStream, mainStream)
+	controllersdk.TestDelete(t, srvApp, sourceApp)
+	controllersdk.TestDelete(t, srvApp, sinkApp)
+}
@@ -0,0 +1,70 @@
+package stream
+
+import (
+	"sort"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/dsl"
+)
+
+// Definition returns the Data Flow DSL of the stream, either as specified or
+// rendered from its topology
+func Definition(stream *core.StreamParameters) string {
+	if stream.Topology != nil {
+		return RenderTopology(stream.Topology)
+	}
+	return stream.Definition
+}
+
+// RenderTopology renders the topology to canonical Data Flow DSL, i.e.
+// ":mytopic > filter --expression='payload != null' | log"
+func RenderTopology(topology *core.StreamTopology) string {
+	return Topology(topology).String()
+}
+
+// Topology maps the topology to its DSL representation
+func Topology(topology *core.StreamTopology) *dsl.StreamNode {
+	stream := &dsl.StreamNode{}
+
+	if topology.SourceDestination != nil {
+		stream.Source = &dsl.DestinationNode{Name: *topology.SourceDestination}
+	} else if topology.Tap != nil {
+		stream.Source = &dsl.DestinationNode{Name: topology.Tap.Stream + "." + topology.Tap.App}
+	}
+
+	for _, app := range topology.Apps {
+		node := &dsl.AppNode{Name: app.Name}
+		if app.Label != nil {
+			node.Label = *app.Label
+		}
+
+		keys := make([]string, 0, len(app.Properties))
+		for key := range app.Properties {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			node.Arguments = append(node.Arguments, &dsl.ArgumentNode{Name: key, Value: app.Properties[key]})
+		}
+		stream.Apps = append(stream.Apps, node)
+	}
+
+	if topology.SinkDestination != nil {
+		stream.Sink = &dsl.DestinationNode{Name: *topology.SinkDestination}
+	}
+
+	return stream
+}
+
+// canonical returns the canonical form of the definition with sensitive
+// values masked as by the server or the definition itself, if it can not be
+// parsed
+func canonical(definition string) string {
+	stream, err := dsl.ParseStream(definition)
+	if err != nil {
+		return definition
+	}
+	stream.Mask()
+	return stream.Canonical()
+}
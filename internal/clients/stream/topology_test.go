@@ -0,0 +1,75 @@
+package stream
+
+import (
+	"testing"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+)
+
+func ptr(value string) *string {
+	return &value
+}
+
+func TestRenderTopology(t *testing.T) {
+	cases := map[string]struct {
+		topology core.StreamTopology
+		want     string
+	}{
+		"Pipe": {
+			topology: core.StreamTopology{
+				Apps: []core.StreamApp{{Name: "time"}, {Name: "log"}},
+			},
+			want: "time | log",
+		},
+		"LabelsAndSortedProperties": {
+			topology: core.StreamTopology{
+				Apps: []core.StreamApp{
+					{Name: "http", Properties: map[string]string{"server.port": "9000", "path-pattern": "/in"}},
+					{Label: ptr("upper"), Name: "transform", Properties: map[string]string{"expression": "payload.toUpperCase()"}},
+					{Name: "log"},
+				},
+			},
+			want: "http --path-pattern=/in --server.port=9000 | upper: transform --expression=payload.toUpperCase() | log",
+		},
+		"QuotedProperties": {
+			topology: core.StreamTopology{
+				Apps: []core.StreamApp{
+					{Name: "filter", Properties: map[string]string{"expression": "payload != 'x'"}},
+					{Name: "log", Properties: map[string]string{"name": "", "level": "a|b", "format": "a,b=c"}},
+				},
+			},
+			want: "filter --expression='payload != ''x''' | log --format=a,b=c --level='a|b' --name=''",
+		},
+		"SourceAndSinkDestination": {
+			topology: core.StreamTopology{
+				SourceDestination: ptr("in"),
+				Apps:              []core.StreamApp{{Name: "transform"}},
+				SinkDestination:   ptr("out"),
+			},
+			want: ":in > transform > :out",
+		},
+		"Tap": {
+			topology: core.StreamTopology{
+				Tap:  &core.StreamTap{Stream: "mainstream", App: "transform"},
+				Apps: []core.StreamApp{{Name: "log"}},
+			},
+			want: ":mainstream.transform > log",
+		},
+		"Bridge": {
+			topology: core.StreamTopology{
+				SourceDestination: ptr("in"),
+				SinkDestination:   ptr("out"),
+			},
+			want: ":in > :out",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := RenderTopology(&tc.topology)
+			if got != tc.want {
+				t.Errorf("expected '%s', got '%s'", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package taskdefinition
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/dsl"
+)
+
+const (
+	errUnknownNode      = "graph references unknown node '%s'"
+	errNodeReused       = "node '%s' is used more than once in the graph"
+	errNodeUnused       = "node '%s' is not used in the graph"
+	errNestedTransition = "transition target '%s' must not have transitions"
+)
+
+// Definition returns the Data Flow DSL of the task, either as specified or
+// rendered from its graph
+func Definition(task *core.TaskDefinitionParameters) (string, error) {
+	if task.Graph != nil {
+		return RenderGraph(task.Graph)
+	}
+	return task.Definition, nil
+}
+
+// RenderGraph renders the graph to canonical composed task DSL, i.e.
+// "a 'FAILED'->cleanup && <b || c: b --x=1> && d"
+func RenderGraph(graph *core.ComposedTaskGraph) (string, error) {
+	task, err := Graph(graph)
+	if err != nil {
+		return "", err
+	}
+	return task.String(), nil
+}
+
+// Graph maps the graph to its DSL representation. Every node has to be used
+// exactly once.
+func Graph(graph *core.ComposedTaskGraph) (*dsl.TaskNode, error) {
+	g := &graphMapper{
+		nodes: make(map[string]*core.ComposedTaskNode, len(graph.Nodes)),
+		used:  map[string]bool{},
+	}
+	for i := range graph.Nodes {
+		g.nodes[graph.Nodes[i].Name] = &graph.Nodes[i]
+	}
+
+	flow := &dsl.FlowNode{}
+	for _, step := range graph.Flow {
+		element, err := g.step(step)
+		if err != nil {
+			return nil, err
+		}
+		flow.Elements = append(flow.Elements, element)
+	}
+
+	for _, node := range graph.Nodes {
+		if !g.used[node.Name] {
+			return nil, errors.Errorf(errNodeUnused, node.Name)
+		}
+	}
+
+	return &dsl.TaskNode{Flow: flow}, nil
+}
+
+type graphMapper struct {
+	nodes map[string]*core.ComposedTaskNode
+	used  map[string]bool
+}
+
+func (g *graphMapper) step(step core.ComposedTaskStep) (dsl.TaskElementNode, error) {
+	if step.Node != nil {
+		return g.app(*step.Node, true)
+	}
+
+	split := &dsl.SplitNode{}
+	for _, sequence := range step.Split {
+		flow := &dsl.FlowNode{}
+		for _, name := range sequence.Nodes {
+			app, err := g.app(name, true)
+			if err != nil {
+				return nil, err
+			}
+			flow.Elements = append(flow.Elements, app)
+		}
+		split.Flows = append(split.Flows, flow)
+	}
+	return split, nil
+}
+
+func (g *graphMapper) app(name string, withTransitions bool) (*dsl.TaskAppNode, error) {
+	if name == dsl.TransitionEnd || name == dsl.TransitionFail {
+		if withTransitions {
+			return nil, errors.Errorf(errUnknownNode, name)
+		}
+		return &dsl.TaskAppNode{Name: name}, nil
+	}
+
+	node, ok := g.nodes[name]
+	if !ok {
+		return nil, errors.Errorf(errUnknownNode, name)
+	}
+	if g.used[name] {
+		return nil, errors.Errorf(errNodeReused, name)
+	}
+	g.used[name] = true
+
+	if !withTransitions && len(node.Transitions) > 0 {
+		return nil, errors.Errorf(errNestedTransition, name)
+	}
+
+	app := &dsl.TaskAppNode{Name: node.App}
+	if node.Name != node.App {
+		app.Label = node.Name
+	}
+
+	keys := make([]string, 0, len(node.Properties))
+	for key := range node.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		app.Arguments = append(app.Arguments, &dsl.ArgumentNode{Name: key, Value: node.Properties[key]})
+	}
+
+	for _, transition := range node.Transitions {
+		target, err := g.app(transition.Target, false)
+		if err != nil {
+			return nil, err
+		}
+		app.Transitions = append(app.Transitions, &dsl.TransitionNode{ExitStatus: transition.ExitStatus, Target: target})
+	}
+	return app, nil
+}
+
+// ChildNames returns the names of the child task definitions, that the
+// server creates for each app of a composed task
+func ChildNames(name string, definition string) []string {
+	task, err := dsl.ParseTask(definition)
+	if err != nil || !task.Composed() {
+		return nil
+	}
+
+	var names []string
+	seen := map[string]bool{}
+	for _, app := range task.Apps() {
+		child := name + "-" + app.LabelOrName()
+		if !seen[child] {
+			seen[child] = true
+			names = append(names, child)
+		}
+	}
+	return names
+}
+
+// canonical returns the canonical form of the definition with masked
+// sensitive arguments, as they are returned by the server, or the definition
+// itself, if it can not be parsed
+func canonical(definition string) string {
+	task, err := dsl.ParseTask(definition)
+	if err != nil {
+		return definition
+	}
+	task.Mask()
+	return task.Canonical()
+}
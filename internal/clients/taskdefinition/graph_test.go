@@ -0,0 +1,156 @@
+package taskdefinition
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/dsl"
+)
+
+func ptr(value string) *string {
+	return &value
+}
+
+func TestRenderGraph(t *testing.T) {
+	cases := map[string]struct {
+		graph   core.ComposedTaskGraph
+		want    string
+		wantErr bool
+	}{
+		"Sequence": {
+			graph: core.ComposedTaskGraph{
+				Nodes: []core.ComposedTaskNode{{Name: "a", App: "a"}, {Name: "b", App: "b"}},
+				Flow:  []core.ComposedTaskStep{{Node: ptr("a")}, {Node: ptr("b")}},
+			},
+			want: "a && b",
+		},
+		"LabelsAndSortedProperties": {
+			graph: core.ComposedTaskGraph{
+				Nodes: []core.ComposedTaskNode{
+					{Name: "first", App: "timestamp", Properties: map[string]string{"format": "yyyy", "a": "x y"}},
+					{Name: "second", App: "timestamp"},
+				},
+				Flow: []core.ComposedTaskStep{{Node: ptr("first")}, {Node: ptr("second")}},
+			},
+			want: "first: timestamp --a='x y' --format=yyyy && second: timestamp",
+		},
+		"Split": {
+			graph: core.ComposedTaskGraph{
+				Nodes: []core.ComposedTaskNode{{Name: "a", App: "a"}, {Name: "b", App: "b"}, {Name: "c", App: "c"}, {Name: "d", App: "d"}},
+				Flow: []core.ComposedTaskStep{
+					{Node: ptr("a")},
+					{Split: []core.ComposedTaskSequence{{Nodes: []string{"b", "c"}}, {Nodes: []string{"d"}}}},
+				},
+			},
+			want: "a && <b && c || d>",
+		},
+		"Transitions": {
+			graph: core.ComposedTaskGraph{
+				Nodes: []core.ComposedTaskNode{
+					{Name: "e", App: "e", Transitions: []core.ComposedTaskTransition{
+						{ExitStatus: "FAILED", Target: "f"},
+						{ExitStatus: "*", Target: "$END"},
+					}},
+					{Name: "f", App: "f"},
+				},
+				Flow: []core.ComposedTaskStep{{Node: ptr("e")}},
+			},
+			want: "e 'FAILED'->f '*'->$END",
+		},
+		"UnknownNode": {
+			graph: core.ComposedTaskGraph{
+				Nodes: []core.ComposedTaskNode{{Name: "a", App: "a"}},
+				Flow:  []core.ComposedTaskStep{{Node: ptr("a")}, {Node: ptr("b")}},
+			},
+			wantErr: true,
+		},
+		"NodeReused": {
+			graph: core.ComposedTaskGraph{
+				Nodes: []core.ComposedTaskNode{{Name: "a", App: "a"}},
+				Flow:  []core.ComposedTaskStep{{Node: ptr("a")}, {Node: ptr("a")}},
+			},
+			wantErr: true,
+		},
+		"NodeUnused": {
+			graph: core.ComposedTaskGraph{
+				Nodes: []core.ComposedTaskNode{{Name: "a", App: "a"}, {Name: "b", App: "b"}},
+				Flow:  []core.ComposedTaskStep{{Node: ptr("a")}},
+			},
+			wantErr: true,
+		},
+		"NestedTransition": {
+			graph: core.ComposedTaskGraph{
+				Nodes: []core.ComposedTaskNode{
+					{Name: "a", App: "a", Transitions: []core.ComposedTaskTransition{{ExitStatus: "FAILED", Target: "b"}}},
+					{Name: "b", App: "b", Transitions: []core.ComposedTaskTransition{{ExitStatus: "FAILED", Target: "$FAIL"}}},
+				},
+				Flow: []core.ComposedTaskStep{{Node: ptr("a")}},
+			},
+			wantErr: true,
+		},
+		"SpecialTargetInFlow": {
+			graph: core.ComposedTaskGraph{
+				Nodes: []core.ComposedTaskNode{{Name: "a", App: "a"}},
+				Flow:  []core.ComposedTaskStep{{Node: ptr("a")}, {Node: ptr("$END")}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := RenderGraph(&tc.graph)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("RenderGraph(...): expected error, got '%s'", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tc.want {
+				t.Errorf("expected '%s', got '%s'", tc.want, got)
+			}
+
+			// The rendered DSL has to be read back as it was rendered
+			parsed, err := dsl.ParseTask(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed.String() != got {
+				t.Errorf("expected '%s' to be parsed back, got '%s'", got, parsed.String())
+			}
+		})
+	}
+}
+
+func TestChildNames(t *testing.T) {
+	cases := map[string]struct {
+		definition string
+		want       []string
+	}{
+		"SingleApp": {
+			definition: "timestamp --format=yyyy",
+		},
+		"Composed": {
+			definition: "a 'FAILED'->cleanup '*'->$END && <b || t2: b> && a",
+			want:       []string{"task-a", "task-cleanup", "task-b", "task-t2"},
+		},
+		"Invalid": {
+			definition: "a &&",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ChildNames("task", tc.definition)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ChildNames(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
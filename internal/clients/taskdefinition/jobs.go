@@ -0,0 +1,257 @@
+package taskdefinition
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients/taskexecution"
+	"github.com/denniskniep/spring-cloud-dataflow-sdk-go/v2/client/jobs"
+	kiota "github.com/microsoft/kiota-abstractions-go"
+)
+
+const (
+	errJobExecutions = "failed to list job executions"
+	errRestartJob    = "failed to restart job execution"
+
+	JobStatusFailed = "FAILED"
+
+	defaultJobExecutionsLimit = 5
+	defaultMaxAttempts        = 3
+)
+
+type JobExecutionListResponse struct {
+	Embedded struct {
+		JobExecutions []JobExecutionResponse `json:"jobExecutionResourceList"`
+	} `json:"_embedded"`
+}
+
+// JobExecutionResponse is returned for each execution by the /jobs/executions endpoint
+type JobExecutionResponse struct {
+	ExecutionId     int64 `json:"executionId"`
+	JobId           int64 `json:"jobId"`
+	TaskExecutionId int64 `json:"taskExecutionId"`
+	JobExecution    struct {
+		Status         string                  `json:"status"`
+		StartTime      *string                 `json:"startTime"`
+		EndTime        *string                 `json:"endTime"`
+		ExitStatus     ExitStatusResponse      `json:"exitStatus"`
+		StepExecutions []StepExecutionResponse `json:"stepExecutions"`
+	} `json:"jobExecution"`
+	Restartable  bool   `json:"restartable"`
+	SchemaTarget string `json:"schemaTarget"`
+}
+
+type StepExecutionResponse struct {
+	StepName   string             `json:"stepName"`
+	Status     string             `json:"status"`
+	ExitStatus ExitStatusResponse `json:"exitStatus"`
+}
+
+type ExitStatusResponse struct {
+	ExitCode        string `json:"exitCode"`
+	ExitDescription string `json:"exitDescription"`
+}
+
+// JobName returns the name of the Spring Batch job run by the task
+func JobName(task *core.TaskDefinitionParameters) string {
+	if task.BatchJob == nil || task.BatchJob.Name == "" {
+		return task.Name
+	}
+	return task.BatchJob.Name
+}
+
+// JobExecutions returns the latest executions of the Spring Batch job, the
+// most recent first
+func (s *TaskDefinitionService) JobExecutions(ctx context.Context, task *core.TaskDefinitionParameters) ([]core.JobExecution, error) {
+	name := JobName(task)
+	requestInfo, err := s.Client().Jobs().Executions().ToGetRequestInformation(ctx, &jobs.ExecutionsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &jobs.ExecutionsRequestBuilderGetQueryParameters{
+			Name: &name,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errJobExecutions)
+	}
+
+	limit := task.BatchJob.ExecutionsLimit
+	if limit <= 0 {
+		limit = defaultJobExecutionsLimit
+	}
+
+	// The generated request builder does not support paging parameters
+	uri, err := requestInfo.GetUri()
+	if err != nil {
+		return nil, errors.Wrap(err, errJobExecutions)
+	}
+	query := uri.Query()
+	query.Set("page", "0")
+	query.Set("size", strconv.Itoa(limit))
+	uri.RawQuery = query.Encode()
+	requestInfo.SetUri(*uri)
+
+	result, err := s.Send(ctx, requestInfo)
+
+	// The job is unknown until it was executed once
+	var apiError *kiota.ApiError
+	if errors.As(err, &apiError) && apiError.ResponseStatusCode == 404 {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, errJobExecutions)
+	}
+
+	var response = JobExecutionListResponse{}
+	err = json.Unmarshal(result, &response)
+	if err != nil {
+		return nil, errors.Wrap(err, errJobExecutions)
+	}
+
+	return JobObservation(response.Embedded.JobExecutions), nil
+}
+
+// RestartJob restarts the failed job execution
+func (s *TaskDefinitionService) RestartJob(ctx context.Context, execution *core.JobExecution) error {
+	config := &jobs.ExecutionsExecutionsItemRequestBuilderPutRequestConfiguration{}
+	if execution.SchemaTarget != "" {
+		config.QueryParameters = &jobs.ExecutionsExecutionsItemRequestBuilderPutQueryParameters{
+			SchemaTarget: &execution.SchemaTarget,
+		}
+	}
+
+	requestInfo, err := s.Client().Jobs().Executions().ByExecutionsIdInt64(execution.ExecutionID).ToPutRequestInformation(ctx, config)
+	if err != nil {
+		return errors.Wrap(err, errRestartJob)
+	}
+
+	// The restart and stop operations share the path and are selected by
+	// parameters, that are missing in the generated request builder
+	uri, err := requestInfo.GetUri()
+	if err != nil {
+		return errors.Wrap(err, errRestartJob)
+	}
+	query := uri.Query()
+	query.Set("restart", "true")
+	uri.RawQuery = query.Encode()
+	requestInfo.SetUri(*uri)
+
+	_, err = s.Send(ctx, requestInfo)
+	if err != nil {
+		return errors.Wrap(err, errRestartJob)
+	}
+	return nil
+}
+
+// JobObservation maps the responses to job executions, the most recent first
+func JobObservation(responses []JobExecutionResponse) []core.JobExecution {
+	executions := make([]core.JobExecution, 0, len(responses))
+	for _, response := range responses {
+		execution := core.JobExecution{
+			ExecutionID:     response.ExecutionId,
+			JobInstanceID:   response.JobId,
+			TaskExecutionID: response.TaskExecutionId,
+			Status:          response.JobExecution.Status,
+			ExitCode:        response.JobExecution.ExitStatus.ExitCode,
+			ExitDescription: response.JobExecution.ExitStatus.ExitDescription,
+			StartTime:       taskexecution.ParseTime(response.JobExecution.StartTime),
+			EndTime:         taskexecution.ParseTime(response.JobExecution.EndTime),
+			Restartable:     response.Restartable,
+			SchemaTarget:    response.SchemaTarget,
+		}
+
+		for _, step := range response.JobExecution.StepExecutions {
+			execution.Steps = append(execution.Steps, core.JobStepExecution{
+				Name:     step.StepName,
+				Status:   step.Status,
+				ExitCode: step.ExitStatus.ExitCode,
+			})
+		}
+		executions = append(executions, execution)
+	}
+
+	sort.Slice(executions, func(i, j int) bool {
+		return executions[i].ExecutionID > executions[j].ExecutionID
+	})
+	return executions
+}
+
+// RestartCandidates returns the restarts of the failed executions, that are
+// the latest execution of their job instance and were neither restarted yet
+// nor exhausted the maximum number of attempts. Failed restart requests are
+// retried as a new attempt.
+func RestartCandidates(executions []core.JobExecution, restarts []core.JobRestart, policy *core.RestartFailedJobs) []core.JobRestart {
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+
+	attempts := map[int64]int{}
+	restarted := map[int64]bool{}
+	for _, restart := range restarts {
+		attempts[restart.JobInstanceID]++
+		if restart.Error == "" {
+			restarted[restart.ExecutionID] = true
+		}
+	}
+
+	var candidates []core.JobRestart
+	latest := map[int64]bool{}
+	for _, execution := range executions {
+		if latest[execution.JobInstanceID] {
+			continue
+		}
+		latest[execution.JobInstanceID] = true
+
+		if execution.Status != JobStatusFailed || !execution.Restartable || restarted[execution.ExecutionID] {
+			continue
+		}
+
+		if attempts[execution.JobInstanceID] >= maxAttempts {
+			continue
+		}
+
+		candidates = append(candidates, core.JobRestart{
+			ExecutionID:   execution.ExecutionID,
+			JobInstanceID: execution.JobInstanceID,
+			Attempt:       attempts[execution.JobInstanceID] + 1,
+		})
+	}
+	return candidates
+}
+
+// TrimRestarts limits the recorded restarts to the most recent ones. The
+// attempts are counted from the records, therefore the records of job
+// instances, that still have observed executions, are always kept.
+func TrimRestarts(restarts []core.JobRestart, executions []core.JobExecution, limit int) []core.JobRestart {
+	if len(restarts) <= limit {
+		return restarts
+	}
+
+	observed := map[int64]bool{}
+	for _, execution := range executions {
+		observed[execution.JobInstanceID] = true
+	}
+
+	kept := 0
+	for _, restart := range restarts {
+		if observed[restart.JobInstanceID] {
+			kept++
+		}
+	}
+
+	var trimmed []core.JobRestart
+	for _, restart := range restarts {
+		if observed[restart.JobInstanceID] {
+			trimmed = append(trimmed, restart)
+		} else if kept < limit {
+			trimmed = append(trimmed, restart)
+			kept++
+		}
+	}
+	return trimmed
+}
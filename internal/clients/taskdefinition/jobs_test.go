@@ -0,0 +1,185 @@
+package taskdefinition
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+)
+
+func TestJobObservation(t *testing.T) {
+	body := `{"_embedded":{"jobExecutionResourceList":[
+		{"executionId":1,"jobId":1,"taskExecutionId":3,"restartable":false,"schemaTarget":"boot2",
+		 "jobExecution":{"status":"COMPLETED","startTime":"2023-08-01T10:00:00.000+00:00","endTime":"2023-08-01T10:05:00.000+00:00",
+		  "exitStatus":{"exitCode":"COMPLETED","exitDescription":""},
+		  "stepExecutions":[{"stepName":"step1","status":"COMPLETED","exitStatus":{"exitCode":"COMPLETED"}}]}},
+		{"executionId":2,"jobId":2,"taskExecutionId":4,"restartable":true,"schemaTarget":"boot2",
+		 "jobExecution":{"status":"FAILED","startTime":"2023-08-02T10:00:00.000+00:00",
+		  "exitStatus":{"exitCode":"FAILED","exitDescription":"java.lang.IllegalStateException"},
+		  "stepExecutions":[{"stepName":"step1","status":"FAILED","exitStatus":{"exitCode":"FAILED"}}]}}
+	]}}`
+
+	var response JobExecutionListResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	start1 := metav1.NewTime(time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC))
+	end1 := metav1.NewTime(time.Date(2023, 8, 1, 10, 5, 0, 0, time.UTC))
+	start2 := metav1.NewTime(time.Date(2023, 8, 2, 10, 0, 0, 0, time.UTC))
+	want := []core.JobExecution{
+		{
+			ExecutionID:     2,
+			JobInstanceID:   2,
+			TaskExecutionID: 4,
+			Status:          "FAILED",
+			ExitCode:        "FAILED",
+			ExitDescription: "java.lang.IllegalStateException",
+			StartTime:       &start2,
+			Restartable:     true,
+			Steps:           []core.JobStepExecution{{Name: "step1", Status: "FAILED", ExitCode: "FAILED"}},
+			SchemaTarget:    "boot2",
+		},
+		{
+			ExecutionID:     1,
+			JobInstanceID:   1,
+			TaskExecutionID: 3,
+			Status:          "COMPLETED",
+			ExitCode:        "COMPLETED",
+			StartTime:       &start1,
+			EndTime:         &end1,
+			Steps:           []core.JobStepExecution{{Name: "step1", Status: "COMPLETED", ExitCode: "COMPLETED"}},
+			SchemaTarget:    "boot2",
+		},
+	}
+
+	got := JobObservation(response.Embedded.JobExecutions)
+	if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b metav1.Time) bool { return a.Equal(&b) })); diff != "" {
+		t.Errorf("JobObservation(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestRestartCandidates(t *testing.T) {
+	executions := []core.JobExecution{
+		{ExecutionID: 6, JobInstanceID: 3, Status: JobStatusFailed, Restartable: true},
+		{ExecutionID: 5, JobInstanceID: 2, Status: "COMPLETED"},
+		{ExecutionID: 4, JobInstanceID: 2, Status: JobStatusFailed, Restartable: true},
+		{ExecutionID: 3, JobInstanceID: 1, Status: JobStatusFailed, Restartable: true},
+		{ExecutionID: 2, JobInstanceID: 1, Status: JobStatusFailed, Restartable: true},
+	}
+
+	cases := map[string]struct {
+		executions []core.JobExecution
+		restarts   []core.JobRestart
+		want       []core.JobRestart
+	}{
+		"LatestFailedOnly": {
+			executions: executions,
+			want: []core.JobRestart{
+				{ExecutionID: 6, JobInstanceID: 3, Attempt: 1},
+				{ExecutionID: 3, JobInstanceID: 1, Attempt: 1},
+			},
+		},
+		"AlreadyRestarted": {
+			executions: executions,
+			restarts: []core.JobRestart{
+				{ExecutionID: 6, JobInstanceID: 3, Attempt: 1},
+				{ExecutionID: 2, JobInstanceID: 1, Attempt: 1},
+			},
+			want: []core.JobRestart{
+				{ExecutionID: 3, JobInstanceID: 1, Attempt: 2},
+			},
+		},
+		"FailedRestartIsRetried": {
+			executions: executions,
+			restarts: []core.JobRestart{
+				{ExecutionID: 6, JobInstanceID: 3, Attempt: 1, Error: "connection refused"},
+				{ExecutionID: 3, JobInstanceID: 1, Attempt: 2},
+				{ExecutionID: 2, JobInstanceID: 1, Attempt: 1},
+			},
+			want: []core.JobRestart{
+				{ExecutionID: 6, JobInstanceID: 3, Attempt: 2},
+			},
+		},
+		"AttemptsExhausted": {
+			executions: executions[3:],
+			restarts: []core.JobRestart{
+				{ExecutionID: 3, JobInstanceID: 1, Attempt: 2, Error: "connection refused"},
+				{ExecutionID: 2, JobInstanceID: 1, Attempt: 1},
+			},
+			want: nil,
+		},
+		"NotRestartable": {
+			executions: []core.JobExecution{
+				{ExecutionID: 1, JobInstanceID: 1, Status: JobStatusFailed},
+			},
+			want: nil,
+		},
+	}
+
+	policy := &core.RestartFailedJobs{MaxAttempts: 2}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := RestartCandidates(tc.executions, tc.restarts, policy)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("RestartCandidates(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTrimRestarts(t *testing.T) {
+	executions := []core.JobExecution{
+		{ExecutionID: 9, JobInstanceID: 1, Status: JobStatusFailed, Restartable: true},
+	}
+
+	cases := map[string]struct {
+		restarts []core.JobRestart
+		want     []core.JobRestart
+	}{
+		"BelowLimit": {
+			restarts: []core.JobRestart{
+				{ExecutionID: 5, JobInstanceID: 2, Attempt: 1},
+			},
+			want: []core.JobRestart{
+				{ExecutionID: 5, JobInstanceID: 2, Attempt: 1},
+			},
+		},
+		"OldestDropped": {
+			restarts: []core.JobRestart{
+				{ExecutionID: 7, JobInstanceID: 4, Attempt: 1},
+				{ExecutionID: 6, JobInstanceID: 3, Attempt: 1},
+				{ExecutionID: 5, JobInstanceID: 2, Attempt: 1},
+			},
+			want: []core.JobRestart{
+				{ExecutionID: 7, JobInstanceID: 4, Attempt: 1},
+				{ExecutionID: 6, JobInstanceID: 3, Attempt: 1},
+			},
+		},
+		"ObservedInstanceKept": {
+			restarts: []core.JobRestart{
+				{ExecutionID: 7, JobInstanceID: 4, Attempt: 1},
+				{ExecutionID: 6, JobInstanceID: 3, Attempt: 1},
+				{ExecutionID: 2, JobInstanceID: 1, Attempt: 2},
+				{ExecutionID: 1, JobInstanceID: 1, Attempt: 1},
+			},
+			want: []core.JobRestart{
+				{ExecutionID: 2, JobInstanceID: 1, Attempt: 2},
+				{ExecutionID: 1, JobInstanceID: 1, Attempt: 1},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := TrimRestarts(tc.restarts, executions, 2)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("TrimRestarts(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
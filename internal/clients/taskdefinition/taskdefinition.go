@@ -3,6 +3,8 @@ package taskdefinition
 import (
 	"context"
 	"encoding/json"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
@@ -10,6 +12,9 @@ import (
 	"github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
 	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
 	"github.com/denniskniep/provider-springclouddataflow/internal/clients"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients/taskexecution"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients/taskschedule"
+	"github.com/denniskniep/provider-springclouddataflow/internal/dsl"
 	"github.com/denniskniep/spring-cloud-dataflow-sdk-go/v2/client/tasks"
 	kiota "github.com/microsoft/kiota-abstractions-go"
 )
@@ -17,13 +22,25 @@ import (
 const (
 	errNotTaskDefinition = "managed resource is not a TaskDefinition custom resource"
 	errConnecting        = "failed to connect"
+	errStopExecutions    = "failed to stop running executions"
+	errStopping          = "stopped running executions %s of task '%s', waiting for them to complete"
+	errValidate          = "failed to validate task definition"
+	errDescribeElement   = "failed to describe composed task element"
+
+	appTypeTask = "task"
+
+	CleanupNone           = "None"
+	CleanupCleanup        = "Cleanup"
+	CleanupStopAndCleanup = "StopAndCleanup"
 )
 
 type TaskDefinitionService struct {
 	clients.DataFlowService
+	executions *taskexecution.TaskExecutionService
+	schedules  *taskschedule.TaskScheduleService
 }
 
-func NewTaskDefinitionService(configData []byte) (clients.Service[*v1alpha1.TaskDefinition, v1alpha1.TaskDefinitionParameters, v1alpha1.TaskDefinitionObservation, TaskDefinitionCompare], error) {
+func NewTaskDefinitionService(configData []byte) (*TaskDefinitionService, error) {
 	dataFlowService, err := clients.NewDataFlowService(configData)
 
 	if err != nil {
@@ -32,6 +49,8 @@ func NewTaskDefinitionService(configData []byte) (clients.Service[*v1alpha1.Task
 
 	return &TaskDefinitionService{
 		*dataFlowService,
+		&taskexecution.TaskExecutionService{DataFlowService: *dataFlowService},
+		&taskschedule.TaskScheduleService{DataFlowService: *dataFlowService},
 	}, nil
 }
 
@@ -48,6 +67,9 @@ type TaskDefinitionDescribeResponse struct {
 	Composed            bool   `json:"composed"`
 	ComposedTaskElement bool   `json:"composedTaskElement"`
 	Status              string `json:"status"`
+
+	// Last execution including its manifest, if requested with manifest=true
+	LastTaskExecution *taskexecution.TaskExecutionResponse `json:"lastTaskExecution"`
 }
 
 func (s *TaskDefinitionService) GetSpec(taskdef *core.TaskDefinition) *core.TaskDefinitionParameters {
@@ -59,6 +81,11 @@ func (s *TaskDefinitionService) GetStatus(taskdef *core.TaskDefinition) *core.Ta
 }
 
 func (s *TaskDefinitionService) SetStatus(taskdef *core.TaskDefinition, status *core.TaskDefinitionObservation) {
+	// The server keeps no record of restarted jobs, deleted schedules and the
+	// applied sensitive arguments
+	status.JobRestarts = taskdef.Status.AtProvider.JobRestarts
+	status.DeletedSchedules = taskdef.Status.AtProvider.DeletedSchedules
+	status.SensitiveArgumentsHash = taskdef.Status.AtProvider.SensitiveArgumentsHash
 	taskdef.Status.AtProvider = *status
 }
 
@@ -68,11 +95,16 @@ func (s *TaskDefinitionService) CreateUniqueIdentifier(spec *core.TaskDefinition
 }
 
 func (s *TaskDefinitionService) Create(ctx context.Context, task *core.TaskDefinitionParameters) error {
-	_, err := s.Client().Tasks().Definitions().Post(ctx, &tasks.DefinitionsRequestBuilderPostRequestConfiguration{
+	definition, err := Definition(task)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.Client().Tasks().Definitions().Post(ctx, &tasks.DefinitionsRequestBuilderPostRequestConfiguration{
 		QueryParameters: &tasks.DefinitionsRequestBuilderPostQueryParameters{
 			Name:        &task.Name,
 			Description: &task.Description,
-			Definition:  &task.Definition,
+			Definition:  &definition,
 		},
 	})
 
@@ -83,12 +115,65 @@ func (s *TaskDefinitionService) Create(ctx context.Context, task *core.TaskDefin
 	return nil
 }
 
+// Update replaces the definition, as definitions can not be updated by the
+// server. The server deletes the schedules of the task with the definition.
 func (s *TaskDefinitionService) Update(ctx context.Context, task *core.TaskDefinitionParameters) error {
-	return errors.New("Update not implemented - all properties are immutable!")
+	err := s.Preflight(ctx, task)
+	if err != nil {
+		return err
+	}
+
+	err = s.DeleteDefinition(ctx, task.Name)
+	if err != nil {
+		return err
+	}
+	return s.Create(ctx, task)
+}
+
+// Preflight validates the definition before an existing one is deleted, by
+// parsing it and checking that all of its apps are registered
+func (s *TaskDefinitionService) Preflight(ctx context.Context, task *core.TaskDefinitionParameters) error {
+	definition, err := Definition(task)
+	if err != nil {
+		return &clients.ValidationError{Name: task.Name, Cause: err}
+	}
+
+	node, err := dsl.ParseTask(definition)
+	if err != nil {
+		return &clients.ValidationError{Name: task.Name, Cause: err}
+	}
+
+	appStatuses := map[string]string{}
+	for _, name := range node.AppNames() {
+		_, err := s.Client().Apps().ByType(appTypeTask).ByName(name).Get(ctx, nil)
+
+		var apiError *kiota.ApiError
+		if errors.As(err, &apiError) && apiError.ResponseStatusCode == 404 {
+			appStatuses[appTypeTask+":"+name] = clients.AppStatusUnregistered
+			continue
+		}
+
+		if err != nil {
+			return errors.Wrap(err, errValidate)
+		}
+
+		appStatuses[appTypeTask+":"+name] = clients.AppStatusValid
+	}
+
+	if !clients.AppStatusesValid(appStatuses) {
+		return &clients.ValidationError{Name: task.Name, AppStatuses: appStatuses}
+	}
+
+	return nil
 }
 
 func (s *TaskDefinitionService) Describe(ctx context.Context, task *core.TaskDefinitionParameters) (*core.TaskDefinitionObservation, error) {
-	result, err := s.Client().Tasks().Definitions().ByName(task.Name).Get(ctx, nil)
+	manifest := true
+	result, err := s.Client().Tasks().Definitions().ByName(task.Name).Get(ctx, &tasks.DefinitionsWithNameItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &tasks.DefinitionsWithNameItemRequestBuilderGetQueryParameters{
+			Manifest: &manifest,
+		},
+	})
 
 	var apiError *kiota.ApiError
 	if errors.As(err, &apiError) && apiError.ResponseStatusCode == 404 {
@@ -105,31 +190,219 @@ func (s *TaskDefinitionService) Describe(ctx context.Context, task *core.TaskDef
 		return nil, err
 	}
 
-	var observed = core.TaskDefinitionObservation{
-		Name:                response.Name,
-		Description:         response.Description,
-		Definition:          response.DslText,
-		Composed:            response.Composed,
-		ComposedTaskElement: response.ComposedTaskElement,
-		Status:              response.Status,
+	observed := response.Observation()
+
+	if response.Composed {
+		observed.ComposedTaskElements, err = s.ComposedTaskElements(ctx, task.Name, response.DslText)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	validation, err := s.Validate(ctx, task.Name)
+	if err != nil {
+		return nil, err
+	}
+	observed.AppStatuses = validation.AppsStatuses
+
+	if task.BatchJob != nil {
+		observed.JobExecutions, err = s.JobExecutions(ctx, task)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return &observed, nil
+	return observed, nil
+}
+
+// Observation maps the response to the observation
+func (r *TaskDefinitionDescribeResponse) Observation() *core.TaskDefinitionObservation {
+	observed := &core.TaskDefinitionObservation{
+		Name:                r.Name,
+		Description:         r.Description,
+		Definition:          r.DslText,
+		Composed:            r.Composed,
+		ComposedTaskElement: r.ComposedTaskElement,
+		Status:              r.Status,
+	}
+
+	last := r.LastTaskExecution
+	if last == nil {
+		return observed
+	}
+
+	observed.LastExecution = &core.TaskExecutionSummary{
+		ExecutionID:  last.ExecutionId,
+		Status:       last.TaskExecutionStatus,
+		ExitCode:     last.ExitCode,
+		ExitMessage:  last.ExitMessage,
+		ErrorMessage: last.ErrorMessage,
+		StartTime:    taskexecution.ParseTime(last.StartTime),
+		EndTime:      taskexecution.ParseTime(last.EndTime),
+		PlatformName: last.PlatformName,
+	}
+
+	// The manifest is only available, once the task was launched by the server
+	if last.ResourceUrl != "" || len(last.AppProperties) > 0 || len(last.DeploymentProperties) > 0 {
+		observed.Manifest = &core.TaskManifest{
+			ResourceURL:          last.ResourceUrl,
+			AppProperties:        last.AppProperties,
+			DeploymentProperties: last.DeploymentProperties,
+			Arguments:            last.Arguments,
+		}
+	}
+	return observed
+}
+
+// ComposedTaskElements returns the child task definitions of the composed
+// task, that exist on the server
+func (s *TaskDefinitionService) ComposedTaskElements(ctx context.Context, name string, definition string) ([]core.ComposedTaskElement, error) {
+	var elements []core.ComposedTaskElement
+	for _, child := range ChildNames(name, definition) {
+		result, err := s.Client().Tasks().Definitions().ByName(child).Get(ctx, nil)
+
+		var apiError *kiota.ApiError
+		if errors.As(err, &apiError) && apiError.ResponseStatusCode == 404 {
+			continue
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, errDescribeElement)
+		}
+
+		var response = TaskDefinitionDescribeResponse{}
+		err = json.Unmarshal(result, &response)
+		if err != nil {
+			return nil, errors.Wrap(err, errDescribeElement)
+		}
+
+		if response.ComposedTaskElement {
+			elements = append(elements, core.ComposedTaskElement{Name: response.Name, Definition: response.DslText})
+		}
+	}
+	return elements, nil
+}
+
+// Validate returns the validation status of each app used by the task
+func (s *TaskDefinitionService) Validate(ctx context.Context, name string) (*clients.ValidationResponse, error) {
+	result, err := s.Client().Tasks().Validation().ByName(name).Get(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errValidate)
+	}
+
+	var response = clients.ValidationResponse{}
+	err = json.Unmarshal(result, &response)
+	if err != nil {
+		return nil, errors.Wrap(err, errValidate)
+	}
+
+	return &response, nil
+}
+
+// DeleteResult is the outcome of deleting a task definition
+type DeleteResult struct {
+	// Existed is false, if the definition was already deleted
+	Existed bool
+
+	// Ids of the running executions, that were stopped instead of deleting
+	Stopped []int64
+
+	// CleanedUp is true, if the executions were cleaned up with the definition
+	CleanedUp bool
 }
 
 func (s *TaskDefinitionService) Delete(ctx context.Context, task *core.TaskDefinitionParameters) error {
-	_, err := s.Client().Tasks().Definitions().ByName(task.Name).Delete(ctx, nil)
+	_, err := s.DeleteWithCleanup(ctx, task)
+	return err
+}
+
+// DeleteWithCleanup deletes the definition according to its cleanupOnDelete
+// policy and returns what was deleted. With StopAndCleanup running executions
+// are stopped and an error is returned, until they completed.
+func (s *TaskDefinitionService) DeleteWithCleanup(ctx context.Context, task *core.TaskDefinitionParameters) (*DeleteResult, error) {
+	result := &DeleteResult{Existed: true}
+
+	if task.CleanupOnDelete == CleanupStopAndCleanup {
+		stopped, err := s.stopRunningExecutions(ctx, task.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		// Executions stop asynchronously, therefore the definition is only
+		// deleted with its executions, once none is running anymore
+		if len(stopped) > 0 {
+			ids := make([]string, 0, len(stopped))
+			for _, id := range stopped {
+				ids = append(ids, strconv.FormatInt(id, 10))
+			}
+			result.Stopped = stopped
+			return result, errors.Errorf(errStopping, strings.Join(ids, ", "), task.Name)
+		}
+	}
+
+	config := &tasks.DefinitionsWithNameItemRequestBuilderDeleteRequestConfiguration{}
+	if task.CleanupOnDelete == CleanupCleanup || task.CleanupOnDelete == CleanupStopAndCleanup {
+		cleanup := true
+		config.QueryParameters = &tasks.DefinitionsWithNameItemRequestBuilderDeleteQueryParameters{
+			Cleanup: &cleanup,
+		}
+		result.CleanedUp = true
+	}
+
+	_, err := s.Client().Tasks().Definitions().ByName(task.Name).Delete(ctx, config)
 
 	var apiError *kiota.ApiError
 	if errors.As(err, &apiError) && apiError.ResponseStatusCode == 404 {
-		return nil
+		return &DeleteResult{}, nil
 	}
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return result, nil
+}
+
+// stopRunningExecutions stops all running executions of the task and
+// returns their ids
+func (s *TaskDefinitionService) stopRunningExecutions(ctx context.Context, name string) ([]int64, error) {
+	executions, err := s.RunningExecutions(ctx, name)
+	if err != nil {
+		return nil, errors.Wrap(err, errStopExecutions)
+	}
+
+	var stopped []int64
+	for _, execution := range executions {
+		err = s.executions.Stop(ctx, execution.ExecutionId, execution.PlatformName)
+		if err != nil {
+			return stopped, errors.Wrap(err, errStopExecutions)
+		}
+		stopped = append(stopped, execution.ExecutionId)
+	}
+	return stopped, nil
+}
+
+// Definitions are compared in their canonical form, because the server does
+// not preserve whitespace and graphs render the properties sorted
+func (s *TaskDefinitionService) MapSpecToCompare(task *core.TaskDefinitionParameters) (*TaskDefinitionCompare, error) {
+	definition, err := Definition(task)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaskDefinitionCompare{
+		Name:        task.Name,
+		Description: task.Description,
+		Definition:  canonical(definition),
+	}, nil
+}
+
+func (s *TaskDefinitionService) MapObservationToCompare(observed *core.TaskDefinitionObservation) (*TaskDefinitionCompare, error) {
+	return &TaskDefinitionCompare{
+		Name:        observed.Name,
+		Description: observed.Description,
+		Definition:  canonical(observed.Definition),
+	}, nil
 }
 
 func (s *TaskDefinitionService) MakeCompare() *TaskDefinitionCompare {
@@ -1,8 +1,14 @@
 package taskdefinition
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
 	"github.com/denniskniep/provider-springclouddataflow/internal/clients/application"
 	"github.com/denniskniep/provider-springclouddataflow/internal/controllersdk"
 )
@@ -22,3 +28,210 @@ func TestCreateTaskDefinition(t *testing.T) {
 	controllersdk.TestDelete(t, srvTask, testTask)
 	controllersdk.TestDelete(t, srvApp, testApp)
 }
+
+func TestDescribeObservation(t *testing.T) {
+	start := metav1.NewTime(time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC))
+	end := metav1.NewTime(time.Date(2023, 8, 1, 10, 5, 0, 0, time.UTC))
+	exitCode := int32(1)
+
+	cases := map[string]struct {
+		body string
+		want *core.TaskDefinitionObservation
+	}{
+		"NeverLaunched": {
+			body: `{"name":"task","description":"desc","dslText":"timestamp","composed":false,"composedTaskElement":false,"status":"UNKNOWN"}`,
+			want: &core.TaskDefinitionObservation{
+				Name:        "task",
+				Description: "desc",
+				Definition:  "timestamp",
+				Status:      "UNKNOWN",
+			},
+		},
+		"LastExecutionWithManifest": {
+			body: `{"name":"task","description":"desc","dslText":"timestamp","status":"ERROR",
+				"lastTaskExecution":{"executionId":7,"exitCode":1,"taskName":"task","exitMessage":"failed",
+				 "startTime":"2023-08-01T10:00:00.000+00:00","endTime":"2023-08-01T10:05:00.000+00:00",
+				 "platformName":"default","taskExecutionStatus":"ERROR","arguments":["--a=1"],
+				 "resourceUrl":"docker:springcloudtask/timestamp-task:3.0.0",
+				 "appProperties":{"timestamp.format":"yyyy"},
+				 "deploymentProperties":{"deployer.timestamp.kubernetes.limits.memory":"512Mi"}}}`,
+			want: &core.TaskDefinitionObservation{
+				Name:        "task",
+				Description: "desc",
+				Definition:  "timestamp",
+				Status:      "ERROR",
+				LastExecution: &core.TaskExecutionSummary{
+					ExecutionID:  7,
+					Status:       "ERROR",
+					ExitCode:     &exitCode,
+					ExitMessage:  "failed",
+					StartTime:    &start,
+					EndTime:      &end,
+					PlatformName: "default",
+				},
+				Manifest: &core.TaskManifest{
+					ResourceURL:          "docker:springcloudtask/timestamp-task:3.0.0",
+					AppProperties:        map[string]string{"timestamp.format": "yyyy"},
+					DeploymentProperties: map[string]string{"deployer.timestamp.kubernetes.limits.memory": "512Mi"},
+					Arguments:            []string{"--a=1"},
+				},
+			},
+		},
+		"LastExecutionWithoutManifest": {
+			body: `{"name":"task","description":"desc","dslText":"timestamp","status":"RUNNING",
+				"lastTaskExecution":{"executionId":8,"taskName":"task","startTime":"2023-08-01T10:00:00.000+00:00",
+				 "taskExecutionStatus":"RUNNING"}}`,
+			want: &core.TaskDefinitionObservation{
+				Name:        "task",
+				Description: "desc",
+				Definition:  "timestamp",
+				Status:      "RUNNING",
+				LastExecution: &core.TaskExecutionSummary{
+					ExecutionID: 8,
+					Status:      "RUNNING",
+					StartTime:   &start,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var response TaskDefinitionDescribeResponse
+			if err := json.Unmarshal([]byte(tc.body), &response); err != nil {
+				t.Fatal(err)
+			}
+
+			got := response.Observation()
+			if diff := cmp.Diff(tc.want, got, cmp.Comparer(func(a, b metav1.Time) bool { return a.Equal(&b) })); diff != "" {
+				t.Errorf("Observation(): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	graph := &core.TaskDefinitionParameters{
+		Name:        "task",
+		Description: "desc",
+		Graph: &core.ComposedTaskGraph{
+			Nodes: []core.ComposedTaskNode{
+				{Name: "a", App: "a", Properties: map[string]string{"y": "2", "x": "1"}},
+				{Name: "b", App: "b"},
+			},
+			Flow: []core.ComposedTaskStep{{Node: ptr("a")}, {Node: ptr("b")}},
+		},
+	}
+
+	cases := map[string]struct {
+		task       *core.TaskDefinitionParameters
+		definition string
+		wantEqual  bool
+	}{
+		"Definition": {
+			task:       &core.TaskDefinitionParameters{Name: "task", Description: "desc", Definition: "a  --y=2 --x=1 &&  b"},
+			definition: "a --x=1 --y=2 && b",
+			wantEqual:  true,
+		},
+		"Graph": {
+			task:       graph,
+			definition: "a --y=2 --x=1 && b",
+			wantEqual:  true,
+		},
+		"GraphChanged": {
+			task:       graph,
+			definition: "a --x=1 --y=2 && <b || c>",
+			wantEqual:  false,
+		},
+		"MaskedArguments": {
+			task:       &core.TaskDefinitionParameters{Name: "task", Description: "desc", Definition: "a --db.password=pw 'FAILED'->b --api-token=t"},
+			definition: "a --db.password=****** 'FAILED'->b --api-token=******",
+			wantEqual:  true,
+		},
+		"MaskedArgumentsChanged": {
+			task:       &core.TaskDefinitionParameters{Name: "task", Description: "desc", Definition: "a --db.password=pw --db.url=jdbc:h2"},
+			definition: "a --db.password=******",
+			wantEqual:  false,
+		},
+	}
+
+	srv := &TaskDefinitionService{}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			observed := &core.TaskDefinitionObservation{Name: "task", Description: "desc", Definition: tc.definition}
+
+			want, err := srv.MapSpecToCompare(tc.task)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, _ := srv.MapObservationToCompare(observed)
+			if equal := cmp.Equal(want, got); equal != tc.wantEqual {
+				t.Errorf("cmp.Equal(...): want %t, got %t:\n%s", tc.wantEqual, equal, cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestPreviousDefinition(t *testing.T) {
+	task := &core.TaskDefinitionParameters{
+		Name:            "task",
+		Description:     "new",
+		CleanupOnDelete: CleanupCleanup,
+		Graph: &core.ComposedTaskGraph{
+			Nodes: []core.ComposedTaskNode{{Name: "a", App: "a"}},
+			Flow:  []core.ComposedTaskStep{{Node: ptr("a")}},
+		},
+	}
+
+	cases := map[string]struct {
+		observed *core.TaskDefinitionObservation
+		applied  map[string]string
+		want     *core.TaskDefinitionParameters
+	}{
+		"Restorable": {
+			observed: &core.TaskDefinitionObservation{Name: "task", Description: "old", Definition: "a && b"},
+			want: &core.TaskDefinitionParameters{
+				Name:            "task",
+				Description:     "old",
+				Definition:      "a && b",
+				CleanupOnDelete: CleanupCleanup,
+			},
+		},
+		"MaskedApplied": {
+			observed: &core.TaskDefinitionObservation{Name: "task", Description: "old", Definition: "a --db.password=****** && b"},
+			applied:  map[string]string{"a.db.password": "pw"},
+			want: &core.TaskDefinitionParameters{
+				Name:            "task",
+				Description:     "old",
+				Definition:      "a --db.password=pw && b",
+				CleanupOnDelete: CleanupCleanup,
+			},
+		},
+		"MaskedUnknown": {
+			observed: &core.TaskDefinitionObservation{Name: "task", Description: "old", Definition: "a --db.password=****** && b"},
+			want:     nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := PreviousDefinition(task, tc.observed, tc.applied)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("PreviousDefinition(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSensitiveArgumentsHash(t *testing.T) {
+	applied := &core.TaskDefinitionParameters{Name: "task", Definition: "a --db.password=pw --format=x 'FAILED'->b"}
+	changed := &core.TaskDefinitionParameters{Name: "task", Definition: "a --db.password=other --format=x 'FAILED'->b"}
+	other := &core.TaskDefinitionParameters{Name: "task", Definition: "a --db.password=pw --format=y 'FAILED'->b"}
+
+	if SensitiveArgumentsHash("uid", applied) == SensitiveArgumentsHash("uid", changed) {
+		t.Errorf("expected different hashes for changed sensitive arguments")
+	}
+	if SensitiveArgumentsHash("uid", applied) != SensitiveArgumentsHash("uid", other) {
+		t.Errorf("expected equal hashes for changed insensitive arguments")
+	}
+}
@@ -0,0 +1,141 @@
+package taskdefinition
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients/taskexecution"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients/taskschedule"
+	"github.com/denniskniep/provider-springclouddataflow/internal/dsl"
+	kiota "github.com/microsoft/kiota-abstractions-go"
+)
+
+const (
+	errRunningExecutions = "failed to get running executions"
+	errSchedules         = "failed to get schedules of task definition"
+	errDeleteDefinition  = "failed to delete task definition"
+)
+
+// Schedule is a schedule of the task definition on a platform
+type Schedule struct {
+	Name           string
+	Platform       string
+	CronExpression string
+}
+
+// DefinitionChanged reports whether the definition or description on the
+// server differ from the desired ones. Both can only be changed by recreating
+// the definition.
+func (s *TaskDefinitionService) DefinitionChanged(task *core.TaskDefinitionParameters, observed *core.TaskDefinitionObservation) bool {
+	desired, err := s.MapSpecToCompare(task)
+	if err != nil {
+		return true
+	}
+	current, _ := s.MapObservationToCompare(observed)
+	return *desired != *current
+}
+
+// RunningExecutions returns the running executions of the task
+func (s *TaskDefinitionService) RunningExecutions(ctx context.Context, name string) ([]taskexecution.TaskExecutionResponse, error) {
+	running, err := s.executions.RunningExecutions(ctx, name)
+
+	var apiError *kiota.ApiError
+	if errors.As(err, &apiError) && apiError.ResponseStatusCode == 404 {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, errRunningExecutions)
+	}
+	return running, nil
+}
+
+// Schedules returns the schedules of the task on all platforms, that
+// support schedules
+func (s *TaskDefinitionService) Schedules(ctx context.Context, name string) ([]Schedule, error) {
+	platforms, err := s.schedules.Platforms(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errSchedules)
+	}
+
+	var schedules []Schedule
+	for _, platform := range platforms {
+		instances, err := s.schedules.Instances(ctx, name, platform.Name)
+		if err != nil {
+			return nil, errors.Wrap(err, errSchedules)
+		}
+
+		for _, instance := range instances {
+			cron, _ := taskschedule.SplitCronExpression(instance.ScheduleProperties)
+			schedules = append(schedules, Schedule{
+				Name:           instance.ScheduleName,
+				Platform:       platform.Name,
+				CronExpression: cron,
+			})
+		}
+	}
+	return schedules, nil
+}
+
+// DeleteDefinition deletes the definition without cleaning up its
+// executions
+func (s *TaskDefinitionService) DeleteDefinition(ctx context.Context, name string) error {
+	_, err := s.Client().Tasks().Definitions().ByName(name).Delete(ctx, nil)
+
+	var apiError *kiota.ApiError
+	if errors.As(err, &apiError) && apiError.ResponseStatusCode == 404 {
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrap(err, errDeleteDefinition)
+	}
+	return nil
+}
+
+// SensitiveArguments returns the sensitive app arguments of the definition
+func SensitiveArguments(task *core.TaskDefinitionParameters) map[string]string {
+	definition, err := Definition(task)
+	if err != nil {
+		return nil
+	}
+
+	node, err := dsl.ParseTask(definition)
+	if err != nil {
+		return nil
+	}
+	return node.SensitiveArguments()
+}
+
+// SensitiveArgumentsHash returns the hash of the sensitive app arguments of
+// the definition. The server masks their values, therefore changes are only
+// detected by comparing the hash with the one recorded when the definition
+// was created.
+func SensitiveArgumentsHash(salt string, task *core.TaskDefinitionParameters) string {
+	return clients.HashProperties(salt, SensitiveArguments(task))
+}
+
+// PreviousDefinition returns the parameters of the definition as it exists
+// on the server, so that it can be restored. Values masked by the server are
+// taken from the applied sensitive arguments. It returns nil, if a masked
+// value is not among them.
+func PreviousDefinition(task *core.TaskDefinitionParameters, observed *core.TaskDefinitionObservation, applied map[string]string) *core.TaskDefinitionParameters {
+	definition := observed.Definition
+	if strings.Contains(definition, dsl.MaskedValue) {
+		node, err := dsl.ParseTask(definition)
+		if err != nil || !node.Unmask(applied) {
+			return nil
+		}
+		definition = node.String()
+	}
+
+	previous := task.DeepCopy()
+	previous.Description = observed.Description
+	previous.Definition = definition
+	previous.Graph = nil
+	return previous
+}
@@ -0,0 +1,142 @@
+package taskexecution
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/denniskniep/spring-cloud-dataflow-sdk-go/v2/client/tasks"
+)
+
+const (
+	errList   = "failed to list task executions"
+	errRemove = "failed to remove task executions"
+
+	pageSize   = 100
+	removeSize = 50
+)
+
+// ActionsCleanup removes the resources of an execution on the platform and
+// its data from the Data Flow database
+var ActionsCleanup = []string{"CLEANUP", "REMOVE_DATA"}
+
+type TaskExecutionListResponse struct {
+	Embedded struct {
+		TaskExecutions []TaskExecutionResponse `json:"taskExecutionResourceList"`
+	} `json:"_embedded"`
+	Page struct {
+		TotalPages int `json:"totalPages"`
+		Number     int `json:"number"`
+	} `json:"page"`
+}
+
+// Executions returns all executions of the task definition
+func (s *TaskExecutionService) Executions(ctx context.Context, name string) ([]TaskExecutionResponse, error) {
+	var executions []TaskExecutionResponse
+	for page := 0; ; page++ {
+		response, err := s.executionsPage(ctx, name, page, pageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		executions = append(executions, response.Embedded.TaskExecutions...)
+		if response.Page.Number+1 >= response.Page.TotalPages {
+			return executions, nil
+		}
+	}
+}
+
+// executionsPage returns a page of the executions of the task definition,
+// the most recent executions first
+func (s *TaskExecutionService) executionsPage(ctx context.Context, name string, page int, size int) (*TaskExecutionListResponse, error) {
+	requestInfo, err := s.Client().Tasks().Executions().ToGetRequestInformation(ctx, &tasks.ExecutionsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &tasks.ExecutionsRequestBuilderGetQueryParameters{
+			Name: &name,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errList)
+	}
+
+	// The generated request builder does not support paging parameters
+	uri, err := requestInfo.GetUri()
+	if err != nil {
+		return nil, errors.Wrap(err, errList)
+	}
+	query := uri.Query()
+	query.Set("page", strconv.Itoa(page))
+	query.Set("size", strconv.Itoa(size))
+	uri.RawQuery = query.Encode()
+	requestInfo.SetUri(*uri)
+
+	result, err := s.Send(ctx, requestInfo)
+	if err != nil {
+		return nil, errors.Wrap(err, errList)
+	}
+
+	var response = TaskExecutionListResponse{}
+	err = json.Unmarshal(result, &response)
+	if err != nil {
+		return nil, errors.Wrap(err, errList)
+	}
+	return &response, nil
+}
+
+// Remove cleans up the resources and removes the data of the executions
+func (s *TaskExecutionService) Remove(ctx context.Context, ids []int64) error {
+	for start := 0; start < len(ids); start += removeSize {
+		end := start + removeSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		joined := make([]string, 0, end-start)
+		for _, id := range ids[start:end] {
+			joined = append(joined, strconv.FormatInt(id, 10))
+		}
+
+		_, err := s.Client().Tasks().Executions().ById(strings.Join(joined, ",")).Delete(ctx, &tasks.ExecutionsExecutionsItemRequestBuilderDeleteRequestConfiguration{
+			QueryParameters: &tasks.ExecutionsExecutionsItemRequestBuilderDeleteQueryParameters{
+				Action: ActionsCleanup,
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, errRemove)
+		}
+	}
+	return nil
+}
+
+// RetentionCandidates returns the ids of all completed executions, that
+// ended more than maxAgeDays ago or are beyond the keepLast most recent
+// completed executions. Running executions are never returned and do not
+// count towards keepLast.
+func RetentionCandidates(executions []TaskExecutionResponse, maxAgeDays *int32, keepLast *int32, now time.Time) []int64 {
+	sorted := make([]TaskExecutionResponse, len(executions))
+	copy(sorted, executions)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ExecutionId > sorted[j].ExecutionId
+	})
+
+	var candidates []int64
+	completed := 0
+	for _, execution := range sorted {
+		observed := execution.Observation()
+		if observed.Status == StatusRunning || observed.EndTime == nil {
+			continue
+		}
+		completed++
+
+		beyondLast := keepLast != nil && completed > int(*keepLast)
+		tooOld := maxAgeDays != nil && observed.EndTime.Time.Before(now.AddDate(0, 0, -int(*maxAgeDays)))
+		if beyondLast || tooOld {
+			candidates = append(candidates, execution.ExecutionId)
+		}
+	}
+	return candidates
+}
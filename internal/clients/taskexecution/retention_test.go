@@ -0,0 +1,61 @@
+package taskexecution
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRetentionCandidates(t *testing.T) {
+	now := time.Date(2023, 8, 10, 12, 0, 0, 0, time.UTC)
+	executions := []TaskExecutionResponse{
+		{ExecutionId: 1, EndTime: ptr("2023-08-01T10:00:00Z"), TaskExecutionStatus: StatusComplete},
+		{ExecutionId: 2, EndTime: ptr("2023-08-05T10:00:00Z"), TaskExecutionStatus: StatusError},
+		{ExecutionId: 3, TaskExecutionStatus: StatusRunning},
+		{ExecutionId: 4, EndTime: ptr("2023-08-09T10:00:00Z"), TaskExecutionStatus: StatusComplete},
+		{ExecutionId: 5, EndTime: ptr("2023-08-10T10:00:00Z"), TaskExecutionStatus: StatusComplete},
+	}
+
+	cases := map[string]struct {
+		maxAgeDays *int32
+		keepLast   *int32
+		want       []int64
+	}{
+		"MaxAge": {
+			maxAgeDays: ptr(int32(7)),
+			want:       []int64{1},
+		},
+		"KeepLast": {
+			keepLast: ptr(int32(2)),
+			want:     []int64{2, 1},
+		},
+		"RunningIsNotKept": {
+			keepLast: ptr(int32(3)),
+			want:     []int64{1},
+		},
+		"RunningIsNeverRemoved": {
+			keepLast: ptr(int32(0)),
+			want:     []int64{5, 4, 2, 1},
+		},
+		"Either": {
+			maxAgeDays: ptr(int32(3)),
+			keepLast:   ptr(int32(4)),
+			want:       []int64{2, 1},
+		},
+		"None": {
+			maxAgeDays: ptr(int32(30)),
+			keepLast:   ptr(int32(10)),
+			want:       nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := RetentionCandidates(executions, tc.maxAgeDays, tc.keepLast, now)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("RetentionCandidates(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
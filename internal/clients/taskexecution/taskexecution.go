@@ -0,0 +1,378 @@
+package taskexecution
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients"
+	"github.com/denniskniep/spring-cloud-dataflow-sdk-go/v2/client/tasks"
+	kiota "github.com/microsoft/kiota-abstractions-go"
+)
+
+const (
+	errConnecting = "failed to connect"
+	errProperties = "failed to resolve properties"
+	errLaunch     = "failed to launch task"
+	errDescribe   = "failed to describe task execution"
+	errStop       = "failed to stop task execution"
+	errCapacity   = "failed to get running task executions"
+	errPlatform   = "platform '%s' does not exist"
+	errFull       = "platform '%s' already runs the maximum of %d task executions"
+
+	// PlatformNameProperty selects the task platform at launch time
+	PlatformNameProperty = "spring.cloud.dataflow.task.platformName"
+
+	StatusRunning  = "RUNNING"
+	StatusComplete = "COMPLETE"
+	StatusError    = "ERROR"
+
+	ConcurrencyForbid  = "Forbid"
+	ConcurrencyAllow   = "Allow"
+	ConcurrencyReplace = "Replace"
+
+	hashArguments = "arguments"
+	hashRunId     = "runId"
+)
+
+type TaskExecutionService struct {
+	clients.DataFlowService
+	kube client.Reader
+}
+
+// NewTaskExecutionService creates the service, kube is used to resolve
+// properties from Secrets and ConfigMaps
+func NewTaskExecutionService(configData []byte, kube client.Reader) (*TaskExecutionService, error) {
+	dataFlowService, err := clients.NewDataFlowService(configData)
+
+	if err != nil {
+		return nil, errors.Wrap(err, errConnecting)
+	}
+
+	return &TaskExecutionService{
+		*dataFlowService,
+		kube,
+	}, nil
+}
+
+// TaskExecutionResponse is returned by the /tasks/executions/{id} endpoint
+type TaskExecutionResponse struct {
+	ExecutionId         int64   `json:"executionId"`
+	TaskName            string  `json:"taskName"`
+	StartTime           *string `json:"startTime"`
+	EndTime             *string `json:"endTime"`
+	ExitCode            *int32  `json:"exitCode"`
+	ExitMessage         string  `json:"exitMessage"`
+	ErrorMessage        string  `json:"errorMessage"`
+	ExternalExecutionId string  `json:"externalExecutionId"`
+	TaskExecutionStatus string  `json:"taskExecutionStatus"`
+	PlatformName        string  `json:"platformName"`
+
+	// Manifest of the execution, only returned for the last execution of a
+	// task definition with manifest=true
+	ResourceUrl          string            `json:"resourceUrl"`
+	AppProperties        map[string]string `json:"appProperties"`
+	DeploymentProperties map[string]string `json:"deploymentProperties"`
+	Arguments            []string          `json:"arguments"`
+}
+
+// CurrentExecutionsResponse is returned for each platform by the
+// /tasks/executions/current endpoint
+type CurrentExecutionsResponse struct {
+	Name                  string `json:"name"`
+	Type                  string `json:"type"`
+	MaximumTaskExecutions int32  `json:"maximumTaskExecutions"`
+	RunningExecutionCount int32  `json:"runningExecutionCount"`
+}
+
+// LaunchProperties returns the resolved properties, the rendered Kubernetes
+// deployer settings and the platform of the launch
+func (s *TaskExecutionService) LaunchProperties(ctx context.Context, task *core.TaskExecutionParameters) (map[string]string, error) {
+	resolved, err := clients.ResolveProperties(ctx, s.kube, task.Properties)
+	if err != nil {
+		return nil, errors.Wrap(err, errProperties)
+	}
+
+	properties, err := clients.KubernetesDeployerProperties(task.KubernetesDeployer)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range resolved {
+		properties[key] = value
+	}
+	properties[PlatformNameProperty] = task.Platform
+	return properties, nil
+}
+
+// Launch launches the task with the already resolved properties and returns
+// the id of the execution
+func (s *TaskExecutionService) Launch(ctx context.Context, task *core.TaskExecutionParameters, properties map[string]string) (int64, error) {
+	joinedProperties := clients.JoinProperties(properties)
+	arguments := clients.JoinArguments(task.Arguments)
+
+	requestInfo, err := s.Client().Tasks().Executions().ToPostRequestInformation(ctx, &tasks.ExecutionsRequestBuilderPostRequestConfiguration{
+		QueryParameters: &tasks.ExecutionsRequestBuilderPostQueryParameters{
+			Name:       task.TaskDefinitionName,
+			Properties: &joinedProperties,
+			Arguments:  &arguments,
+		},
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, errLaunch)
+	}
+
+	result, err := s.Send(ctx, requestInfo)
+	if err != nil {
+		return 0, errors.Wrap(err, errLaunch)
+	}
+
+	id, err := strconv.ParseInt(strings.TrimSpace(string(result)), 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, errLaunch)
+	}
+	return id, nil
+}
+
+// LatestExecutionId returns the id of the most recent execution of the task
+// or 0, if it was never launched
+func (s *TaskExecutionService) LatestExecutionId(ctx context.Context, name string) (int64, error) {
+	response, err := s.executionsPage(ctx, name, 0, 1)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(response.Embedded.TaskExecutions) == 0 {
+		return 0, nil
+	}
+	return response.Embedded.TaskExecutions[0].ExecutionId, nil
+}
+
+// LaunchedAfter returns the id of the first execution of the task launched
+// after the given execution or nil, if there is none
+func (s *TaskExecutionService) LaunchedAfter(ctx context.Context, name string, after int64) (*int64, error) {
+	response, err := s.executionsPage(ctx, name, 0, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var first *int64
+	for _, execution := range response.Embedded.TaskExecutions {
+		id := execution.ExecutionId
+		if id > after && (first == nil || id < *first) {
+			first = &id
+		}
+	}
+	return first, nil
+}
+
+// LaunchHash returns the hash of everything, that launches the task again
+// once changed
+func LaunchHash(salt string, task *core.TaskExecutionParameters, properties map[string]string) string {
+	values := make(map[string]string, len(properties)+2)
+	for key, value := range properties {
+		values[key] = value
+	}
+
+	values[hashArguments] = strings.Join(task.Arguments, " ")
+	if task.RunID != nil {
+		values[hashRunId] = *task.RunID
+	}
+	return clients.HashProperties(salt, values)
+}
+
+// CheckCapacity returns an error, if the platform already runs the maximum
+// number of concurrent task executions
+func (s *TaskExecutionService) CheckCapacity(ctx context.Context, platform string) error {
+	response, err := s.currentExecutions(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, current := range response {
+		if current.Name != platform {
+			continue
+		}
+
+		if current.RunningExecutionCount >= current.MaximumTaskExecutions {
+			return errors.Errorf(errFull, platform, current.MaximumTaskExecutions)
+		}
+		return nil
+	}
+	return errors.Errorf(errPlatform, platform)
+}
+
+// RunningExecutions returns the running executions of the task. The
+// platforms report how many executions they run in total, therefore the
+// executions are only listed until as many running ones were found.
+func (s *TaskExecutionService) RunningExecutions(ctx context.Context, name string) ([]TaskExecutionResponse, error) {
+	current, err := s.currentExecutions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := 0
+	for _, platform := range current {
+		remaining += int(platform.RunningExecutionCount)
+	}
+
+	var running []TaskExecutionResponse
+	for page := 0; remaining > 0; page++ {
+		response, err := s.executionsPage(ctx, name, page, pageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, execution := range response.Embedded.TaskExecutions {
+			if execution.TaskExecutionStatus == StatusRunning {
+				running = append(running, execution)
+				remaining--
+			}
+		}
+
+		if response.Page.Number+1 >= response.Page.TotalPages {
+			break
+		}
+	}
+	return running, nil
+}
+
+// currentExecutions returns the number of running executions of every platform
+func (s *TaskExecutionService) currentExecutions(ctx context.Context) ([]CurrentExecutionsResponse, error) {
+	result, err := s.Client().Tasks().Executions().Current().Get(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errCapacity)
+	}
+
+	var response []CurrentExecutionsResponse
+	err = json.Unmarshal(result, &response)
+	if err != nil {
+		return nil, errors.Wrap(err, errCapacity)
+	}
+	return response, nil
+}
+
+// Describe returns the execution or nil, if it does not exist
+func (s *TaskExecutionService) Describe(ctx context.Context, id int64) (*core.TaskExecutionObservation, error) {
+	result, err := s.Client().Tasks().Executions().ByIdInt64(id).Get(ctx, nil)
+
+	var apiError *kiota.ApiError
+	if errors.As(err, &apiError) && apiError.ResponseStatusCode == 404 {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, errDescribe)
+	}
+
+	var response = TaskExecutionResponse{}
+	err = json.Unmarshal(result, &response)
+	if err != nil {
+		return nil, errors.Wrap(err, errDescribe)
+	}
+
+	return response.Observation(), nil
+}
+
+// Stop stops the running execution on the platform it was launched on
+func (s *TaskExecutionService) Stop(ctx context.Context, id int64, platform string) error {
+	_, err := s.Client().Tasks().Executions().ByIdInt64(id).Post(ctx, &tasks.ExecutionsExecutionsItemRequestBuilderPostRequestConfiguration{
+		QueryParameters: &tasks.ExecutionsExecutionsItemRequestBuilderPostQueryParameters{
+			Platform: &platform,
+		},
+	})
+
+	if err != nil {
+		return errors.Wrap(err, errStop)
+	}
+	return nil
+}
+
+// Observation maps the response to the observation
+func (r *TaskExecutionResponse) Observation() *core.TaskExecutionObservation {
+	id := r.ExecutionId
+	return &core.TaskExecutionObservation{
+		ExecutionID:         &id,
+		TaskName:            r.TaskName,
+		Status:              r.TaskExecutionStatus,
+		StartTime:           ParseTime(r.StartTime),
+		EndTime:             ParseTime(r.EndTime),
+		ExitCode:            r.ExitCode,
+		ExitMessage:         r.ExitMessage,
+		ErrorMessage:        r.ErrorMessage,
+		ExternalExecutionID: r.ExternalExecutionId,
+	}
+}
+
+// AppendHistory prepends the superseded execution to the history, which is
+// limited to the most recent executions
+func AppendHistory(history []core.TaskExecutionRecord, observed *core.TaskExecutionObservation, limit int) []core.TaskExecutionRecord {
+	if observed.ExecutionID == nil {
+		return history
+	}
+
+	record := core.TaskExecutionRecord{
+		ExecutionID: *observed.ExecutionID,
+		Status:      observed.Status,
+		ExitCode:    observed.ExitCode,
+		ExitMessage: observed.ExitMessage,
+		StartTime:   observed.StartTime,
+		EndTime:     observed.EndTime,
+	}
+
+	history = append([]core.TaskExecutionRecord{record}, history...)
+	if len(history) > limit {
+		history = history[:limit]
+	}
+	return history
+}
+
+// ParseTime parses the ISO-8601 timestamps of the server, i.e.
+// 2023-08-01T10:15:30.123+00:00. Unknown formats are omitted.
+func ParseTime(value *string) *metav1.Time {
+	if value == nil {
+		return nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, *value)
+	if err != nil {
+		return nil
+	}
+
+	t := metav1.NewTime(parsed)
+	return &t
+}
+
+// Condition maps the execution to the Ready condition. Only successfully
+// completed executions are ready.
+func Condition(observed *core.TaskExecutionObservation) xpv1.Condition {
+	switch observed.Status {
+	case StatusComplete:
+		return xpv1.Available()
+	case StatusError:
+		return core.Failed().WithMessage(failureMessage(observed))
+	default:
+		return core.Running()
+	}
+}
+
+func failureMessage(observed *core.TaskExecutionObservation) string {
+	if observed.ExitMessage != "" {
+		return observed.ExitMessage
+	}
+	if observed.ErrorMessage != "" {
+		return observed.ErrorMessage
+	}
+	if observed.ExitCode != nil {
+		return "exit code " + strconv.Itoa(int(*observed.ExitCode))
+	}
+	return "task execution failed"
+}
@@ -0,0 +1,169 @@
+package taskexecution
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/google/go-cmp/cmp"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+)
+
+func TestObservation(t *testing.T) {
+	cases := map[string]struct {
+		response   string
+		wantStatus string
+		wantStart  *time.Time
+		wantEnd    bool
+		wantReason xpv1.ConditionReason
+		wantMsg    string
+	}{
+		"Running": {
+			response:   `{"executionId": 1, "taskName": "mytask", "startTime": "2023-08-01T10:15:30.123+00:00", "endTime": null, "exitCode": null, "taskExecutionStatus": "RUNNING"}`,
+			wantStatus: StatusRunning,
+			wantStart:  ptr(time.Date(2023, 8, 1, 10, 15, 30, 123000000, time.UTC)),
+			wantReason: "Running",
+		},
+		"Complete": {
+			response:   `{"executionId": 2, "taskName": "mytask", "startTime": "2023-08-01T10:15:30Z", "endTime": "2023-08-01T10:16:00Z", "exitCode": 0, "taskExecutionStatus": "COMPLETE"}`,
+			wantStatus: StatusComplete,
+			wantStart:  ptr(time.Date(2023, 8, 1, 10, 15, 30, 0, time.UTC)),
+			wantEnd:    true,
+			wantReason: xpv1.ReasonAvailable,
+		},
+		"ErrorWithExitMessage": {
+			response:   `{"executionId": 3, "taskName": "mytask", "endTime": "2023-08-01T10:16:00Z", "exitCode": 1, "exitMessage": "input missing", "errorMessage": "java.lang.IllegalStateException", "taskExecutionStatus": "ERROR"}`,
+			wantStatus: StatusError,
+			wantEnd:    true,
+			wantReason: "Failed",
+			wantMsg:    "input missing",
+		},
+		"ErrorWithExitCode": {
+			response:   `{"executionId": 4, "taskName": "mytask", "endTime": "2023-08-01T10:16:00Z", "exitCode": 2, "taskExecutionStatus": "ERROR"}`,
+			wantStatus: StatusError,
+			wantEnd:    true,
+			wantReason: "Failed",
+			wantMsg:    "exit code 2",
+		},
+		"UnknownTimeFormat": {
+			response:   `{"executionId": 5, "taskName": "mytask", "startTime": "01.08.2023", "taskExecutionStatus": "UNKNOWN"}`,
+			wantStatus: "UNKNOWN",
+			wantReason: "Running",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var response TaskExecutionResponse
+			if err := json.Unmarshal([]byte(tc.response), &response); err != nil {
+				t.Fatal(err)
+			}
+
+			got := response.Observation()
+			if got.Status != tc.wantStatus {
+				t.Errorf("expected status '%s', got '%s'", tc.wantStatus, got.Status)
+			}
+
+			if tc.wantStart == nil && got.StartTime != nil {
+				t.Errorf("expected no start time, got %v", got.StartTime)
+			}
+			if tc.wantStart != nil && (got.StartTime == nil || !got.StartTime.Time.Equal(*tc.wantStart)) {
+				t.Errorf("expected start time %v, got %v", tc.wantStart, got.StartTime)
+			}
+
+			if tc.wantEnd != (got.EndTime != nil) {
+				t.Errorf("expected end time %v, got %v", tc.wantEnd, got.EndTime)
+			}
+
+			condition := Condition(got)
+			if condition.Reason != tc.wantReason || condition.Message != tc.wantMsg {
+				t.Errorf("expected condition '%s: %s', got '%s: %s'", tc.wantReason, tc.wantMsg, condition.Reason, condition.Message)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestLaunchHash(t *testing.T) {
+	task := core.TaskExecutionParameters{Arguments: []string{"--a=1"}}
+	properties := map[string]string{"app.task.x": "1"}
+	hash := LaunchHash("uid", &task, properties)
+
+	cases := map[string]struct {
+		task       core.TaskExecutionParameters
+		properties map[string]string
+		wantEqual  bool
+	}{
+		"Unchanged": {
+			task:       core.TaskExecutionParameters{Arguments: []string{"--a=1"}},
+			properties: map[string]string{"app.task.x": "1"},
+			wantEqual:  true,
+		},
+		"Arguments": {
+			task:       core.TaskExecutionParameters{Arguments: []string{"--a=2"}},
+			properties: map[string]string{"app.task.x": "1"},
+		},
+		"Properties": {
+			task:       core.TaskExecutionParameters{Arguments: []string{"--a=1"}},
+			properties: map[string]string{"app.task.x": "2"},
+		},
+		"RunId": {
+			task:       core.TaskExecutionParameters{Arguments: []string{"--a=1"}, RunID: ptr("v2")},
+			properties: map[string]string{"app.task.x": "1"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := LaunchHash("uid", &tc.task, tc.properties)
+			if (got == hash) != tc.wantEqual {
+				t.Errorf("expected equal hash %v, got '%s' and '%s'", tc.wantEqual, hash, got)
+			}
+		})
+	}
+}
+
+func TestAppendHistory(t *testing.T) {
+	history := []core.TaskExecutionRecord{{ExecutionID: 2, Status: StatusComplete}, {ExecutionID: 1, Status: StatusError}}
+
+	cases := map[string]struct {
+		observed core.TaskExecutionObservation
+		limit    int
+		want     []int64
+	}{
+		"Prepend": {
+			observed: core.TaskExecutionObservation{ExecutionID: ptr(int64(3)), Status: StatusComplete},
+			limit:    10,
+			want:     []int64{3, 2, 1},
+		},
+		"Limit": {
+			observed: core.TaskExecutionObservation{ExecutionID: ptr(int64(3)), Status: StatusRunning},
+			limit:    2,
+			want:     []int64{3, 2},
+		},
+		"NotLaunched": {
+			observed: core.TaskExecutionObservation{},
+			limit:    10,
+			want:     []int64{2, 1},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := AppendHistory(history, &tc.observed, tc.limit)
+
+			ids := make([]int64, 0, len(got))
+			for _, record := range got {
+				ids = append(ids, record.ExecutionID)
+			}
+			if diff := cmp.Diff(tc.want, ids); diff != "" {
+				t.Errorf("AppendHistory(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package taskschedule
+
+import (
+	"strings"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+)
+
+const (
+	// CronExpressionProperty is the schedule property holding the cron expression
+	CronExpressionProperty = "scheduler.cron.expression"
+
+	// TimeZoneProperty sets the time zone of the Kubernetes CronJob
+	TimeZoneProperty = "scheduler.kubernetes.timeZone"
+
+	// The server qualifies scheduler and deployer properties with this prefix
+	qualifiedPrefix = "spring.cloud."
+)
+
+// Cron expressions, arguments and properties are compared in their
+// normalized form, because the server qualifies property keys and does not
+// preserve whitespace
+func (s *TaskScheduleService) MapSpecToCompare(task *core.TaskScheduleParameters) (*TaskScheduleCompare, error) {
+	return &TaskScheduleCompare{
+		ScheduleName:       task.ScheduleName,
+		TaskDefinitionName: task.TaskDefinitionName,
+		CronExpression:     NormalizeCronExpression(task.CronExpression),
+		TimeZone:           task.TimeZone,
+		Arguments:          *AppliedArguments(task),
+		Properties:         SpecProperties(task),
+	}, nil
+}
+
+func (s *TaskScheduleService) MapObservationToCompare(observed *core.TaskScheduleObservation) (*TaskScheduleCompare, error) {
+	return &TaskScheduleCompare{
+		ScheduleName:       observed.ScheduleName,
+		TaskDefinitionName: observed.TaskDefinitionName,
+		CronExpression:     NormalizeCronExpression(observed.CronExpression),
+		TimeZone:           observed.TimeZone,
+		Arguments:          NormalizeArguments(observed.Arguments),
+		Properties:         normalizeProperties(observed.Properties),
+	}, nil
+}
+
+// SplitCronExpression returns the cron expression and the remaining
+// schedule properties with normalized keys
+func SplitCronExpression(scheduleProperties map[string]string) (string, map[string]string) {
+	cron := ""
+	properties := map[string]string{}
+	for key, value := range normalizeProperties(scheduleProperties) {
+		if key == CronExpressionProperty {
+			cron = value
+			continue
+		}
+		properties[key] = value
+	}
+	return cron, properties
+}
+
+// SplitTimeZone returns the time zone and the remaining normalized schedule
+// properties
+func SplitTimeZone(scheduleProperties map[string]string) (string, map[string]string) {
+	properties := normalizeProperties(scheduleProperties)
+	timeZone := properties[TimeZoneProperty]
+	delete(properties, TimeZoneProperty)
+	if len(properties) == 0 {
+		return timeZone, nil
+	}
+	return timeZone, properties
+}
+
+// ObservedProperties returns the reported properties, that were applied by
+// properties. Other properties are never kept, as the values of
+// propertiesFrom may contain secrets. Properties, that the scheduler does not
+// report, are assumed to keep their applied value.
+func ObservedProperties(task *core.TaskScheduleParameters, applied map[string]string, reported map[string]string) map[string]string {
+	observed := map[string]string{}
+	for _, keys := range []map[string]string{SpecProperties(task), applied} {
+		for key := range keys {
+			if value, ok := reported[key]; ok {
+				observed[key] = value
+			} else if value, ok := applied[key]; ok {
+				observed[key] = value
+			}
+		}
+	}
+
+	if len(observed) == 0 {
+		return nil
+	}
+	return observed
+}
+
+// SpecProperties returns the normalized properties and propertyMap of the
+// schedule without the cron expression, propertiesFrom and kubernetesDeployer
+func SpecProperties(task *core.TaskScheduleParameters) map[string]string {
+	properties := map[string]string{}
+	if task.Properties != nil {
+		properties = SplitProperties(*task.Properties)
+	}
+	for key, value := range task.PropertyMap {
+		properties[key] = value
+	}
+	return normalizeProperties(properties)
+}
+
+// AppliedArguments returns the normalized arguments, as they are recorded
+// in the status
+func AppliedArguments(task *core.TaskScheduleParameters) *string {
+	joined := JoinedArguments(task)
+	arguments := NormalizeArguments(&joined)
+	return &arguments
+}
+
+// SplitProperties parses comma separated key=value pairs. A part without
+// '=' continues the value of the previous property, so that values may
+// contain commas, i.e. jobAnnotations=a:1,b:2. Values enclosed in double
+// quotes are continued until the closing quote.
+func SplitProperties(joined string) map[string]string {
+	properties := map[string]string{}
+	last := ""
+	quoted := false
+	for _, part := range strings.Split(joined, ",") {
+		key, value, found := strings.Cut(part, "=")
+		if !found || quoted {
+			if last != "" {
+				properties[last] = properties[last] + "," + part
+				quoted = quoted && !strings.HasSuffix(strings.TrimSpace(part), `"`)
+			}
+			continue
+		}
+
+		last = strings.TrimSpace(key)
+		properties[last] = value
+		value = strings.TrimSpace(value)
+		quoted = strings.HasPrefix(value, `"`) && !isQuoted(value)
+	}
+	return properties
+}
+
+// NormalizeCronExpression collapses the whitespace between the fields
+func NormalizeCronExpression(cron string) string {
+	return strings.Join(strings.Fields(cron), " ")
+}
+
+// NormalizeArguments collapses the whitespace between the arguments
+func NormalizeArguments(arguments *string) string {
+	if arguments == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(*arguments), " ")
+}
+
+func normalizeProperties(properties map[string]string) map[string]string {
+	if len(properties) == 0 {
+		return nil
+	}
+
+	normalized := make(map[string]string, len(properties))
+	for key, value := range properties {
+		key = strings.TrimPrefix(strings.TrimSpace(key), qualifiedPrefix)
+		normalized[key] = unquote(strings.TrimSpace(value))
+	}
+	return normalized
+}
+
+// unquote removes the double quotes, that enclose values containing commas
+// when joining the properties
+func unquote(value string) string {
+	if isQuoted(value) {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+func isQuoted(value string) bool {
+	return len(value) > 1 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`)
+}
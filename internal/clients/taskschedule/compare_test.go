@@ -0,0 +1,206 @@
+package taskschedule
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestSplitProperties(t *testing.T) {
+	cases := map[string]struct {
+		joined string
+		want   map[string]string
+	}{
+		"Simple": {
+			joined: "a=1,b=2",
+			want:   map[string]string{"a": "1", "b": "2"},
+		},
+		"CommaInValue": {
+			joined: "scheduler.kubernetes.jobAnnotations=annotation1:value1,annotation2:value2,scheduler.kubernetes.secretRefs=[my-secret]",
+			want: map[string]string{
+				"scheduler.kubernetes.jobAnnotations": "annotation1:value1,annotation2:value2",
+				"scheduler.kubernetes.secretRefs":     "[my-secret]",
+			},
+		},
+		"EqualsInValue": {
+			joined: "app.a.query=x=1",
+			want:   map[string]string{"app.a.query": "x=1"},
+		},
+		"QuotedValue": {
+			joined: `deployer.task.kubernetes.secretRefs="[db,api]",deployer.task.kubernetes.query="x=1,y=2"`,
+			want: map[string]string{
+				"deployer.task.kubernetes.secretRefs": `"[db,api]"`,
+				"deployer.task.kubernetes.query":      `"x=1,y=2"`,
+			},
+		},
+		"Empty": {
+			joined: "",
+			want:   map[string]string{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := SplitProperties(tc.joined)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("SplitProperties(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJoinedPropertiesRoundTrip(t *testing.T) {
+	properties := map[string]string{
+		"deployer.task.kubernetes.secretRefs":     "[db,api]",
+		"deployer.task.kubernetes.jobAnnotations": "a:1,b:2",
+		"deployer.task.kubernetes.query":          "x=1,y=2",
+		"app.task.greeting":                       "hello",
+	}
+
+	got := normalizeProperties(SplitProperties(clients.JoinProperties(properties)))
+	if diff := cmp.Diff(properties, got); diff != "" {
+		t.Errorf("normalizeProperties(SplitProperties(JoinProperties(...))): -want, +got:\n%s", diff)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	task := &core.TaskScheduleParameters{
+		ScheduleName:       "schedule",
+		TaskDefinitionName: ptr("task"),
+		CronExpression:     "*/5  * * * *",
+		Arguments:          ptr("--a=1  --b=2"),
+		Properties:         ptr("scheduler.kubernetes.jobAnnotations=a:1,b:2"),
+	}
+
+	cases := map[string]struct {
+		scheduleProperties map[string]string
+		applied            map[string]string
+		arguments          *string
+		wantEqual          bool
+	}{
+		"UpToDate": {
+			scheduleProperties: map[string]string{
+				"spring.cloud.scheduler.cron.expression":           "*/5 * * * *",
+				"spring.cloud.scheduler.kubernetes.jobAnnotations": "a:1,b:2",
+			},
+			arguments: ptr("--a=1 --b=2"),
+			wantEqual: true,
+		},
+		"QuotedPropertyReported": {
+			scheduleProperties: map[string]string{
+				"spring.cloud.scheduler.cron.expression":           "*/5 * * * *",
+				"spring.cloud.scheduler.kubernetes.jobAnnotations": `"a:1,b:2"`,
+			},
+			arguments: ptr("--a=1 --b=2"),
+			wantEqual: true,
+		},
+		"PropertiesNotReported": {
+			scheduleProperties: map[string]string{
+				"spring.cloud.scheduler.cron.expression": "*/5 * * * *",
+			},
+			arguments: ptr("--a=1 --b=2"),
+			wantEqual: true,
+		},
+		"PropertiesNotReportedChangedInSpec": {
+			scheduleProperties: map[string]string{
+				"spring.cloud.scheduler.cron.expression": "*/5 * * * *",
+			},
+			applied:   map[string]string{"scheduler.kubernetes.jobAnnotations": "a:1"},
+			arguments: ptr("--a=1 --b=2"),
+			wantEqual: false,
+		},
+		"CronChanged": {
+			scheduleProperties: map[string]string{
+				"spring.cloud.scheduler.cron.expression": "*/10 * * * *",
+			},
+			arguments: ptr("--a=1 --b=2"),
+			wantEqual: false,
+		},
+		"PropertyChanged": {
+			scheduleProperties: map[string]string{
+				"spring.cloud.scheduler.cron.expression":           "*/5 * * * *",
+				"spring.cloud.scheduler.kubernetes.jobAnnotations": "a:1",
+			},
+			arguments: ptr("--a=1 --b=2"),
+			wantEqual: false,
+		},
+		"PropertyNotReported": {
+			scheduleProperties: map[string]string{
+				"spring.cloud.scheduler.cron.expression":      "*/5 * * * *",
+				"spring.cloud.scheduler.kubernetes.namespace": "default",
+			},
+			arguments: ptr("--a=1 --b=2"),
+			wantEqual: true,
+		},
+		"PropertyNotReportedChangedInSpec": {
+			scheduleProperties: map[string]string{
+				"spring.cloud.scheduler.cron.expression":      "*/5 * * * *",
+				"spring.cloud.scheduler.kubernetes.namespace": "default",
+			},
+			applied:   map[string]string{"scheduler.kubernetes.jobAnnotations": "a:1"},
+			arguments: ptr("--a=1 --b=2"),
+			wantEqual: false,
+		},
+		"AppliedPropertyRemovedFromSpec": {
+			scheduleProperties: map[string]string{
+				"spring.cloud.scheduler.cron.expression":           "*/5 * * * *",
+				"spring.cloud.scheduler.kubernetes.jobAnnotations": "a:1,b:2",
+			},
+			applied: map[string]string{
+				"scheduler.kubernetes.jobAnnotations": "a:1,b:2",
+				"scheduler.kubernetes.namespace":      "default",
+			},
+			arguments: ptr("--a=1 --b=2"),
+			wantEqual: false,
+		},
+		"TimeZoneChanged": {
+			scheduleProperties: map[string]string{
+				"spring.cloud.scheduler.cron.expression":     "*/5 * * * *",
+				"spring.cloud.scheduler.kubernetes.timeZone": "Europe/Berlin",
+			},
+			arguments: ptr("--a=1 --b=2"),
+			wantEqual: false,
+		},
+		"ArgumentsChanged": {
+			scheduleProperties: map[string]string{
+				"spring.cloud.scheduler.cron.expression": "*/5 * * * *",
+			},
+			arguments: ptr("--a=1"),
+			wantEqual: false,
+		},
+	}
+
+	srv := &TaskScheduleService{}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			applied := tc.applied
+			if applied == nil {
+				applied = SpecProperties(task)
+			}
+
+			cron, reported := SplitCronExpression(tc.scheduleProperties)
+			timeZone, reported := SplitTimeZone(reported)
+			observed := &core.TaskScheduleObservation{
+				ScheduleName:       "schedule",
+				TaskDefinitionName: ptr("task"),
+				CronExpression:     cron,
+				TimeZone:           timeZone,
+				Arguments:          tc.arguments,
+				Properties:         ObservedProperties(task, applied, reported),
+			}
+
+			want, _ := srv.MapSpecToCompare(task)
+			got, _ := srv.MapObservationToCompare(observed)
+			if equal := cmp.Equal(want, got); equal != tc.wantEqual {
+				t.Errorf("cmp.Equal(...): want %t, got %t:\n%s", tc.wantEqual, equal, cmp.Diff(want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package taskschedule
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	errKubernetesFields = "the Kubernetes scheduler expects 5 fields (minute hour day-of-month month day-of-week), got %d"
+	errQuartzFields     = "the %s scheduler expects 6 or 7 fields in Quartz format (second minute hour day-of-month month day-of-week [year]), got %d"
+	errQuartzYear       = "invalid year '%s' in Quartz cron expression, expected years between 1970 and 2099"
+	errCronExpression   = "invalid cron expression '%s'"
+	errTimeZone         = "unknown time zone '%s'"
+	errTimeZonePlatform = "the %s scheduler does not support time zones"
+
+	// PlatformTypeKubernetes schedules tasks as CronJobs
+	PlatformTypeKubernetes = "kubernetes"
+)
+
+// quartzParser parses Quartz expressions, once the year is removed and the
+// days of week are converted. Kubernetes parses CronJobs with the standard
+// parser.
+var quartzParser = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+var (
+	// The last day of the month with an optional offset, the last weekday of
+	// the month and the weekday nearest to the given day
+	quartzDayOfMonthToken = regexp.MustCompile(`^(?i)(L(-([1-9]|[12][0-9]|30))?|LW|([1-9]|[12][0-9]|3[01])W)$`)
+
+	// The last given day of the week in the month and the nth given day of
+	// the week in the month
+	quartzDayOfWeekToken = regexp.MustCompile(`^(?i)(([1-7]|SUN|MON|TUE|WED|THU|FRI|SAT)?L|([1-7]|SUN|MON|TUE|WED|THU|FRI|SAT)#[1-5])$`)
+
+	quartzYearPart = regexp.MustCompile(`^(\*|(\d{4})(-(\d{4}))?)(/[1-9]\d*)?$`)
+)
+
+// ParseCronExpression parses the cron expression in the format of the
+// platform type. Kubernetes expects the 5 field format of CronJobs, other
+// schedulers the 6 or 7 field Quartz format with seconds. The fire times of
+// Quartz expressions with a year or with the tokens L, W and # can not be
+// previewed, for them the schedule is nil.
+func ParseCronExpression(expression string, platformType string) (cron.Schedule, error) {
+	fields := strings.Fields(expression)
+	descriptor := len(fields) == 1 && strings.HasPrefix(fields[0], "@")
+
+	if strings.EqualFold(platformType, PlatformTypeKubernetes) {
+		if len(fields) != 5 && !descriptor {
+			return nil, errors.Errorf(errKubernetesFields, len(fields))
+		}
+
+		schedule, err := cron.ParseStandard(expression)
+		if err != nil {
+			return nil, errors.Wrapf(err, errCronExpression, expression)
+		}
+		return schedule, nil
+	}
+
+	if descriptor {
+		schedule, err := quartzParser.Parse(expression)
+		if err != nil {
+			return nil, errors.Wrapf(err, errCronExpression, expression)
+		}
+		return schedule, nil
+	}
+
+	if len(fields) != 6 && len(fields) != 7 {
+		return nil, errors.Errorf(errQuartzFields, platformType, len(fields))
+	}
+
+	preview := true
+	if len(fields) == 7 {
+		if fields[6] != "*" && fields[6] != "?" {
+			if !validQuartzYear(fields[6]) {
+				return nil, errors.Errorf(errQuartzYear, fields[6])
+			}
+			preview = false
+		}
+		fields = fields[:6]
+	}
+
+	// Tokens without a preview are replaced, so that the remaining fields are
+	// still validated
+	if quartzDayOfMonthToken.MatchString(fields[3]) {
+		fields[3] = "?"
+		preview = false
+	}
+	if quartzDayOfWeekToken.MatchString(fields[5]) {
+		fields[5] = "?"
+		preview = false
+	}
+
+	fields[5] = quartzDayOfWeek(fields[5])
+	schedule, err := quartzParser.Parse(strings.Join(fields, " "))
+	if err != nil {
+		return nil, errors.Wrapf(err, errCronExpression, expression)
+	}
+
+	if !preview {
+		return nil, nil
+	}
+	return schedule, nil
+}
+
+// validQuartzYear reports whether the year field consists of years, ranges
+// and steps between 1970 and 2099, as supported by Quartz
+func validQuartzYear(field string) bool {
+	for _, part := range strings.Split(field, ",") {
+		match := quartzYearPart.FindStringSubmatch(part)
+		if match == nil {
+			return false
+		}
+
+		for _, bound := range []string{match[2], match[4]} {
+			if bound == "" {
+				continue
+			}
+			year, _ := strconv.Atoi(bound)
+			if year < 1970 || year > 2099 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ParseTimeZone loads the time zone from the tz database. Only Kubernetes
+// supports time zones, an empty time zone is UTC.
+func ParseTimeZone(timeZone string, platformType string) (*time.Location, error) {
+	if timeZone == "" {
+		return time.UTC, nil
+	}
+
+	if !strings.EqualFold(platformType, PlatformTypeKubernetes) {
+		return nil, errors.Errorf(errTimeZonePlatform, platformType)
+	}
+
+	// Local depends on the provider and is rejected by Kubernetes
+	if timeZone == time.Local.String() {
+		return nil, errors.Errorf(errTimeZone, timeZone)
+	}
+
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, errors.Wrapf(err, errTimeZone, timeZone)
+	}
+	return location, nil
+}
+
+// NextScheduledTimes returns the next fire times of the schedule after from
+// or nil, if the schedule can not be previewed
+func NextScheduledTimes(schedule cron.Schedule, from time.Time, count int) []metav1.Time {
+	if schedule == nil {
+		return nil
+	}
+
+	times := make([]metav1.Time, 0, count)
+	next := from
+	// Schedules fire in the location of from
+	for i := 0; i < count; i++ {
+		next = schedule.Next(next)
+		if next.IsZero() {
+			break
+		}
+		times = append(times, metav1.NewTime(next))
+	}
+	return times
+}
+
+// quartzDayOfWeek converts the numeric days of Quartz, which start with
+// 1 for Sunday, to the days of the standard format starting with 0. Steps
+// are kept as they are.
+func quartzDayOfWeek(field string) string {
+	parts := strings.Split(field, ",")
+	for i, part := range parts {
+		days, step, hasStep := strings.Cut(part, "/")
+
+		bounds := strings.Split(days, "-")
+		for j, bound := range bounds {
+			day, err := strconv.Atoi(bound)
+			if err == nil {
+				bounds[j] = strconv.Itoa(day - 1)
+			}
+		}
+
+		parts[i] = strings.Join(bounds, "-")
+		if hasStep {
+			parts[i] = parts[i] + "/" + step
+		}
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,230 @@
+package taskschedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseCronExpression(t *testing.T) {
+	from := time.Date(2023, 8, 10, 12, 0, 0, 0, time.UTC) // Thursday
+
+	cases := map[string]struct {
+		expression   string
+		platformType string
+		wantErr      bool
+		noPreview    bool
+		want         []time.Time
+	}{
+		"Kubernetes": {
+			expression:   "*/30 * * * *",
+			platformType: "Kubernetes",
+			want: []time.Time{
+				time.Date(2023, 8, 10, 12, 30, 0, 0, time.UTC),
+				time.Date(2023, 8, 10, 13, 0, 0, 0, time.UTC),
+			},
+		},
+		"KubernetesDescriptor": {
+			expression:   "@daily",
+			platformType: "Kubernetes",
+			want: []time.Time{
+				time.Date(2023, 8, 11, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 8, 12, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		"KubernetesQuartz": {
+			expression:   "0 */30 * * * ?",
+			platformType: "Kubernetes",
+			wantErr:      true,
+		},
+		"KubernetesInvalidField": {
+			expression:   "61 * * * *",
+			platformType: "Kubernetes",
+			wantErr:      true,
+		},
+		"Quartz": {
+			expression:   "0 0 6 ? * MON-FRI",
+			platformType: "Cloud Foundry",
+			want: []time.Time{
+				time.Date(2023, 8, 11, 6, 0, 0, 0, time.UTC),
+				time.Date(2023, 8, 14, 6, 0, 0, 0, time.UTC),
+			},
+		},
+		"QuartzNumericDays": {
+			expression:   "0 0 6 ? * 2,7",
+			platformType: "Cloud Foundry",
+			want: []time.Time{
+				time.Date(2023, 8, 12, 6, 0, 0, 0, time.UTC),
+				time.Date(2023, 8, 14, 6, 0, 0, 0, time.UTC),
+			},
+		},
+		"QuartzYear": {
+			expression:   "30 0 6 * * ? *",
+			platformType: "Cloud Foundry",
+			want: []time.Time{
+				time.Date(2023, 8, 11, 6, 0, 30, 0, time.UTC),
+				time.Date(2023, 8, 12, 6, 0, 30, 0, time.UTC),
+			},
+		},
+		"QuartzSpecificYear": {
+			expression:   "0 0 6 * * ? 2024",
+			platformType: "Cloud Foundry",
+			noPreview:    true,
+		},
+		"QuartzYearRange": {
+			expression:   "0 0 6 * * ? 2024-2030/2,2035",
+			platformType: "Cloud Foundry",
+			noPreview:    true,
+		},
+		"QuartzYearOutOfRange": {
+			expression:   "0 0 6 * * ? 2100",
+			platformType: "Cloud Foundry",
+			wantErr:      true,
+		},
+		"QuartzLastDayOfMonth": {
+			expression:   "0 0 6 L * ?",
+			platformType: "Cloud Foundry",
+			noPreview:    true,
+		},
+		"QuartzLastDayOfMonthOffset": {
+			expression:   "0 0 6 L-3 * ?",
+			platformType: "Cloud Foundry",
+			noPreview:    true,
+		},
+		"QuartzNearestWeekday": {
+			expression:   "0 0 6 15W * ?",
+			platformType: "Cloud Foundry",
+			noPreview:    true,
+		},
+		"QuartzLastWeekday": {
+			expression:   "0 0 6 LW * ?",
+			platformType: "Cloud Foundry",
+			noPreview:    true,
+		},
+		"QuartzLastDayOfWeek": {
+			expression:   "0 0 6 ? * 6L",
+			platformType: "Cloud Foundry",
+			noPreview:    true,
+		},
+		"QuartzNthDayOfWeek": {
+			expression:   "0 0 6 ? * MON#2 2024",
+			platformType: "Cloud Foundry",
+			noPreview:    true,
+		},
+		"QuartzInvalidNthDayOfWeek": {
+			expression:   "0 0 6 ? * MON#6",
+			platformType: "Cloud Foundry",
+			wantErr:      true,
+		},
+		"QuartzInvalidFieldWithToken": {
+			expression:   "0 0 25 L * ?",
+			platformType: "Cloud Foundry",
+			wantErr:      true,
+		},
+		"QuartzStandard": {
+			expression:   "*/30 * * * *",
+			platformType: "Cloud Foundry",
+			wantErr:      true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := ParseCronExpression(tc.expression, tc.platformType)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("ParseCronExpression(...): expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.noPreview {
+				if schedule != nil {
+					t.Errorf("ParseCronExpression(...): expected no schedule to preview")
+				}
+				return
+			}
+
+			var got []time.Time
+			for _, next := range NextScheduledTimes(schedule, from, len(tc.want)) {
+				got = append(got, next.Time)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NextScheduledTimes(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseTimeZone(t *testing.T) {
+	from := time.Date(2023, 10, 28, 0, 0, 0, 0, time.UTC) // Daylight saving time ends on Sunday
+
+	cases := map[string]struct {
+		timeZone     string
+		platformType string
+		wantErr      bool
+		want         []time.Time
+	}{
+		"Empty": {
+			platformType: "Cloud Foundry",
+			want: []time.Time{
+				time.Date(2023, 10, 28, 6, 0, 0, 0, time.UTC),
+				time.Date(2023, 10, 29, 6, 0, 0, 0, time.UTC),
+			},
+		},
+		"Kubernetes": {
+			timeZone:     "Europe/Berlin",
+			platformType: "Kubernetes",
+			want: []time.Time{
+				time.Date(2023, 10, 28, 4, 0, 0, 0, time.UTC),
+				time.Date(2023, 10, 29, 5, 0, 0, 0, time.UTC),
+			},
+		},
+		"Unknown": {
+			timeZone:     "Europe/Atlantis",
+			platformType: "Kubernetes",
+			wantErr:      true,
+		},
+		"Local": {
+			timeZone:     "Local",
+			platformType: "Kubernetes",
+			wantErr:      true,
+		},
+		"NotSupported": {
+			timeZone:     "Europe/Berlin",
+			platformType: "Cloud Foundry",
+			wantErr:      true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			location, err := ParseTimeZone(tc.timeZone, tc.platformType)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("ParseTimeZone(...): expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			schedule, err := ParseCronExpression("0 6 * * *", PlatformTypeKubernetes)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []time.Time
+			for _, next := range NextScheduledTimes(schedule, from.In(location), len(tc.want)) {
+				got = append(got, next.Time.UTC())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NextScheduledTimes(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
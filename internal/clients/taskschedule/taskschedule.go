@@ -3,8 +3,10 @@ package taskschedule
 import (
 	"context"
 	"encoding/json"
+	"strconv"
 
 	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
 	"github.com/denniskniep/provider-springclouddataflow/internal/clients"
@@ -15,13 +17,24 @@ import (
 const (
 	errConnecting      = "failed to connect"
 	errNotTaskSchedule = "managed resource is not a TaskSchedule custom resource"
+	errProperties      = "failed to resolve properties"
+	errUnschedule      = "failed to unschedule task"
+	errReschedule      = "failed to reschedule task"
+	errPlatforms       = "failed to get task platforms"
+	errInstances       = "failed to get schedules of task"
+
+	// Schedules of a task definition are not paged by the provider
+	instancesPageSize = 1000
 )
 
 type TaskScheduleService struct {
 	clients.DataFlowService
+	kube client.Reader
 }
 
-func NewTaskScheduleService(configData []byte) (*TaskScheduleService, error) {
+// NewTaskScheduleService creates the service, kube is used to resolve
+// properties from Secrets and ConfigMaps
+func NewTaskScheduleService(configData []byte, kube client.Reader) (*TaskScheduleService, error) {
 	dataFlowService, err := clients.NewDataFlowService(configData)
 
 	if err != nil {
@@ -30,12 +43,24 @@ func NewTaskScheduleService(configData []byte) (*TaskScheduleService, error) {
 
 	return &TaskScheduleService{
 		*dataFlowService,
+		kube,
 	}, nil
 }
 
 type TaskScheduleCompare struct {
-	ScheduleName       string  `json:"scheduleName"`
-	TaskDefinitionName *string `json:"taskDefinitionName,omitempty"`
+	ScheduleName       string            `json:"scheduleName"`
+	TaskDefinitionName *string           `json:"taskDefinitionName,omitempty"`
+	CronExpression     string            `json:"cronExpression"`
+	TimeZone           string            `json:"timeZone,omitempty"`
+	Arguments          string            `json:"arguments"`
+	Properties         map[string]string `json:"properties,omitempty"`
+}
+
+// TaskScheduleDescribeResponse is returned by the /tasks/schedules/{name} endpoint
+type TaskScheduleDescribeResponse struct {
+	ScheduleName       string            `json:"scheduleName"`
+	TaskDefinitionName *string           `json:"taskDefinitionName"`
+	ScheduleProperties map[string]string `json:"scheduleProperties"`
 }
 
 func (s *TaskScheduleService) GetSpec(taskdef *core.TaskSchedule) *core.TaskScheduleParameters {
@@ -47,6 +72,17 @@ func (s *TaskScheduleService) GetStatus(taskdef *core.TaskSchedule) *core.TaskSc
 }
 
 func (s *TaskScheduleService) SetStatus(taskdef *core.TaskSchedule, status *core.TaskScheduleObservation) {
+	// The server reports neither the applied hash and arguments nor the
+	// next fire times. Schedulers, that do not report the time zone, are
+	// assumed to keep it unchanged.
+	recorded := taskdef.Status.AtProvider
+	if status.TimeZone == "" {
+		status.TimeZone = recorded.TimeZone
+	}
+	status.PropertiesHash = recorded.PropertiesHash
+	status.Arguments = recorded.Arguments
+	status.NextScheduledTimes = recorded.NextScheduledTimes
+	status.Properties = ObservedProperties(&taskdef.Spec.ForProvider, recorded.Properties, status.Properties)
 	taskdef.Status.AtProvider = *status
 }
 
@@ -55,19 +91,43 @@ func (s *TaskScheduleService) CreateUniqueIdentifier(spec *core.TaskSchedulePara
 	return &uniqueId, nil
 }
 
-func (s *TaskScheduleService) Create(ctx context.Context, task *core.TaskScheduleParameters) error {
+// ResolvedProperties returns propertiesFrom with the values of all referenced
+// Secrets and ConfigMaps and the rendered Kubernetes deployer settings
+func (s *TaskScheduleService) ResolvedProperties(ctx context.Context, task *core.TaskScheduleParameters) (map[string]string, error) {
+	resolved, err := clients.ResolveProperties(ctx, s.kube, task.PropertiesFrom)
+	if err != nil {
+		return nil, errors.Wrap(err, errProperties)
+	}
 
-	properties := "scheduler.cron.expression=" + task.CronExpression
-	if task.Properties != nil {
-		properties = properties + "," + *task.Properties
+	properties, err := clients.KubernetesDeployerProperties(task.KubernetesDeployer)
+	if err != nil {
+		return nil, err
 	}
+	for key, value := range resolved {
+		properties[key] = value
+	}
+	return properties, nil
+}
+
+func (s *TaskScheduleService) Create(ctx context.Context, task *core.TaskScheduleParameters) error {
+	resolved, err := s.ResolvedProperties(ctx, task)
+	if err != nil {
+		return err
+	}
+	return s.CreateWithProperties(ctx, task, resolved)
+}
+
+// CreateWithProperties schedules the task with the already resolved propertiesFrom
+func (s *TaskScheduleService) CreateWithProperties(ctx context.Context, task *core.TaskScheduleParameters, resolved map[string]string) error {
+	properties := JoinedProperties(task, resolved)
+	arguments := JoinedArguments(task)
 
 	err := s.Client().Tasks().Schedules().Post(ctx, &tasks.SchedulesRequestBuilderPostRequestConfiguration{
 		QueryParameters: &tasks.SchedulesRequestBuilderPostQueryParameters{
 			ScheduleName:       &task.ScheduleName,
 			TaskDefinitionName: task.TaskDefinitionName,
 			Platform:           &task.Platform,
-			Arguments:          task.Arguments,
+			Arguments:          &arguments,
 			Properties:         &properties,
 		},
 	})
@@ -79,12 +139,125 @@ func (s *TaskScheduleService) Create(ctx context.Context, task *core.TaskSchedul
 	return nil
 }
 
+// JoinedProperties renders the cron expression, the time zone, the properties and the
+// resolved propertiesFrom, which take precedence over propertyMap. The
+// deprecated properties string is passed as is.
+func JoinedProperties(task *core.TaskScheduleParameters, resolved map[string]string) string {
+	properties := map[string]string{}
+	for key, value := range task.PropertyMap {
+		properties[key] = value
+	}
+	for key, value := range resolved {
+		properties[key] = value
+	}
+
+	joined := CronExpressionProperty + "=" + task.CronExpression
+	if task.TimeZone != "" {
+		joined = joined + "," + TimeZoneProperty + "=" + task.TimeZone
+	}
+	if task.Properties != nil && *task.Properties != "" {
+		joined = joined + "," + *task.Properties
+	}
+	if len(properties) > 0 {
+		joined = joined + "," + clients.JoinProperties(properties)
+	}
+	return joined
+}
+
+// JoinedArguments renders argumentList or passes the deprecated arguments
+// string as is
+func JoinedArguments(task *core.TaskScheduleParameters) string {
+	if len(task.ArgumentList) > 0 {
+		return clients.JoinArguments(task.ArgumentList)
+	}
+	if task.Arguments != nil {
+		return *task.Arguments
+	}
+	return ""
+}
+
 func (s *TaskScheduleService) Update(ctx context.Context, task *core.TaskScheduleParameters) error {
-	return errors.New("Update of TaskSchedule not implemented - all properties are immutable!")
+	resolved, err := s.ResolvedProperties(ctx, task)
+	if err != nil {
+		return err
+	}
+	return s.Reschedule(ctx, task, resolved)
+}
+
+// Reschedule replaces the schedule, as schedules can not be updated by the server
+func (s *TaskScheduleService) Reschedule(ctx context.Context, task *core.TaskScheduleParameters, resolved map[string]string) error {
+	err := s.Delete(ctx, task)
+	if err != nil {
+		return errors.Wrap(err, errUnschedule)
+	}
+
+	err = s.CreateWithProperties(ctx, task, resolved)
+	if err != nil {
+		return errors.Wrap(err, errReschedule)
+	}
+
+	return nil
+}
+
+// PreviousSchedule returns the parameters of the schedule as it was applied
+// last, so that it can be restored. The current values of propertiesFrom are
+// used, as only their hash is kept.
+func PreviousSchedule(task *core.TaskScheduleParameters, observed *core.TaskScheduleObservation) *core.TaskScheduleParameters {
+	previous := task.DeepCopy()
+	previous.CronExpression = observed.CronExpression
+	previous.TimeZone = observed.TimeZone
+	previous.Arguments = observed.Arguments
+	previous.ArgumentList = nil
+	previous.Properties = nil
+	previous.PropertyMap = observed.Properties
+	return previous
+}
+
+// PlatformListResponse is returned by the /tasks/platforms endpoint
+type PlatformListResponse struct {
+	Embedded struct {
+		Platforms []clients.Platform `json:"launcherResourceList"`
+	} `json:"_embedded"`
+}
+
+// Platforms returns the task platforms, that support schedules
+func (s *TaskScheduleService) Platforms(ctx context.Context) ([]clients.Platform, error) {
+	schedulesEnabled := "true"
+	result, err := s.Client().Tasks().Platforms().Get(ctx, &tasks.PlatformsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &tasks.PlatformsRequestBuilderGetQueryParameters{
+			SchedulesEnabled: &schedulesEnabled,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errPlatforms)
+	}
+
+	var response = PlatformListResponse{}
+	err = json.Unmarshal(result, &response)
+	if err != nil {
+		return nil, errors.Wrap(err, errPlatforms)
+	}
+
+	return response.Embedded.Platforms, nil
+}
+
+// Platform returns the platform or a *clients.PlatformError, if the server
+// does not know the platform or it does not support schedules
+func (s *TaskScheduleService) Platform(ctx context.Context, name string) (*clients.Platform, error) {
+	platforms, err := s.Platforms(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return clients.FindPlatform(platforms, name)
 }
 
 func (s *TaskScheduleService) Describe(ctx context.Context, task *core.TaskScheduleParameters) (*core.TaskScheduleObservation, error) {
-	result, err := s.Client().Tasks().Schedules().BySchedulesId(task.ScheduleName).Get(ctx, nil)
+	result, err := s.Client().Tasks().Schedules().BySchedulesId(task.ScheduleName).Get(ctx, &tasks.SchedulesSchedulesItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &tasks.SchedulesSchedulesItemRequestBuilderGetQueryParameters{
+			Platform: &task.Platform,
+		},
+	})
 
 	var apiError *kiota.ApiError
 	if errors.As(err, &apiError) && apiError.ResponseStatusCode == 404 {
@@ -95,17 +268,81 @@ func (s *TaskScheduleService) Describe(ctx context.Context, task *core.TaskSched
 		return nil, err
 	}
 
-	var observed = core.TaskScheduleObservation{}
-	err = json.Unmarshal(result, &observed)
+	var response = TaskScheduleDescribeResponse{}
+	err = json.Unmarshal(result, &response)
 	if err != nil {
 		return nil, err
 	}
 
+	// The reported properties are reduced to the applied properties in SetStatus
+	cron, reported := SplitCronExpression(response.ScheduleProperties)
+	timeZone, reported := SplitTimeZone(reported)
+	var observed = core.TaskScheduleObservation{
+		ScheduleName:       response.ScheduleName,
+		TaskDefinitionName: response.TaskDefinitionName,
+		CronExpression:     cron,
+		TimeZone:           timeZone,
+		Properties:         reported,
+	}
+
 	return &observed, nil
 }
 
+// ScheduleListResponse is returned by the
+// /tasks/schedules/instances/{taskDefinitionName} endpoint
+type ScheduleListResponse struct {
+	Embedded struct {
+		Schedules []TaskScheduleDescribeResponse `json:"scheduleInfoResourceList"`
+	} `json:"_embedded"`
+}
+
+// Instances returns the schedules of the task definition on the platform
+func (s *TaskScheduleService) Instances(ctx context.Context, taskDefinitionName string, platform string) ([]TaskScheduleDescribeResponse, error) {
+	requestInfo, err := s.Client().Tasks().Schedules().Instances().ByTaskDefinitionName(taskDefinitionName).ToGetRequestInformation(ctx, &tasks.SchedulesInstancesWithTaskDefinitionNameItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &tasks.SchedulesInstancesWithTaskDefinitionNameItemRequestBuilderGetQueryParameters{
+			Platform: &platform,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errInstances)
+	}
+
+	// The generated request builder does not support paging parameters
+	uri, err := requestInfo.GetUri()
+	if err != nil {
+		return nil, errors.Wrap(err, errInstances)
+	}
+	query := uri.Query()
+	query.Set("size", strconv.Itoa(instancesPageSize))
+	uri.RawQuery = query.Encode()
+	requestInfo.SetUri(*uri)
+
+	result, err := s.Send(ctx, requestInfo)
+
+	var apiError *kiota.ApiError
+	if errors.As(err, &apiError) && apiError.ResponseStatusCode == 404 {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, errInstances)
+	}
+
+	var response = ScheduleListResponse{}
+	err = json.Unmarshal(result, &response)
+	if err != nil {
+		return nil, errors.Wrap(err, errInstances)
+	}
+
+	return response.Embedded.Schedules, nil
+}
+
 func (s *TaskScheduleService) Delete(ctx context.Context, task *core.TaskScheduleParameters) error {
-	_, err := s.Client().Tasks().Schedules().BySchedulesId(task.ScheduleName).Delete(ctx, nil)
+	_, err := s.Client().Tasks().Schedules().BySchedulesId(task.ScheduleName).Delete(ctx, &tasks.SchedulesSchedulesItemRequestBuilderDeleteRequestConfiguration{
+		QueryParameters: &tasks.SchedulesSchedulesItemRequestBuilderDeleteQueryParameters{
+			Platform: &task.Platform,
+		},
+	})
 
 	var apiError *kiota.ApiError
 	if errors.As(err, &apiError) && apiError.ResponseStatusCode == 404 {
@@ -0,0 +1,123 @@
+package taskschedule
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+)
+
+func TestPreviousSchedule(t *testing.T) {
+	task := &core.TaskScheduleParameters{
+		ScheduleName:       "schedule",
+		TaskDefinitionName: ptr("task"),
+		CronExpression:     "*/10 * * * *",
+		Platform:           "default",
+		Arguments:          ptr("--a=2"),
+		Properties:         ptr("b=2"),
+	}
+	observed := &core.TaskScheduleObservation{
+		ScheduleName:       "schedule",
+		TaskDefinitionName: ptr("task"),
+		CronExpression:     "*/5 * * * *",
+		Arguments:          ptr("--a=1"),
+		Properties:         map[string]string{"b": "1"},
+	}
+
+	want := &core.TaskScheduleParameters{
+		ScheduleName:       "schedule",
+		TaskDefinitionName: ptr("task"),
+		CronExpression:     "*/5 * * * *",
+		Platform:           "default",
+		Arguments:          ptr("--a=1"),
+		PropertyMap:        map[string]string{"b": "1"},
+	}
+
+	got := PreviousSchedule(task, observed)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("PreviousSchedule(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestJoinedProperties(t *testing.T) {
+	cases := map[string]struct {
+		task     *core.TaskScheduleParameters
+		resolved map[string]string
+		want     string
+	}{
+		"PropertyMap": {
+			task: &core.TaskScheduleParameters{
+				CronExpression: "*/5 * * * *",
+				PropertyMap: map[string]string{
+					"scheduler.kubernetes.jobAnnotations": "annotation1:value1,annotation2:value2",
+					"scheduler.kubernetes.secretRefs":     "[my-secret]",
+				},
+			},
+			want: "scheduler.cron.expression=*/5 * * * *," +
+				"scheduler.kubernetes.jobAnnotations=\"annotation1:value1,annotation2:value2\"," +
+				"scheduler.kubernetes.secretRefs=[my-secret]",
+		},
+		"ResolvedTakesPrecedence": {
+			task: &core.TaskScheduleParameters{
+				CronExpression: "* * * * *",
+				PropertyMap:    map[string]string{"app.a.password": "plain"},
+			},
+			resolved: map[string]string{"app.a.password": "secret"},
+			want:     "scheduler.cron.expression=* * * * *,app.a.password=secret",
+		},
+		"TimeZone": {
+			task: &core.TaskScheduleParameters{
+				CronExpression: "0 6 * * *",
+				TimeZone:       "Europe/Berlin",
+			},
+			want: "scheduler.cron.expression=0 6 * * *,scheduler.kubernetes.timeZone=Europe/Berlin",
+		},
+		"DeprecatedString": {
+			task: &core.TaskScheduleParameters{
+				CronExpression: "* * * * *",
+				Properties:     ptr("scheduler.kubernetes.jobAnnotations=a:1,b:2"),
+				PropertyMap:    map[string]string{"app.a.b": "c"},
+			},
+			want: "scheduler.cron.expression=* * * * *,scheduler.kubernetes.jobAnnotations=a:1,b:2,app.a.b=c",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := JoinedProperties(tc.task, tc.resolved)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("JoinedProperties(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJoinedArguments(t *testing.T) {
+	cases := map[string]struct {
+		task *core.TaskScheduleParameters
+		want string
+	}{
+		"ArgumentList": {
+			task: &core.TaskScheduleParameters{ArgumentList: []string{"--a=1", "--b=x y"}},
+			want: `--a=1 --b="x y"`,
+		},
+		"DeprecatedString": {
+			task: &core.TaskScheduleParameters{Arguments: ptr("--a=1 --b=2")},
+			want: "--a=1 --b=2",
+		},
+		"None": {
+			task: &core.TaskScheduleParameters{},
+			want: "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := JoinedArguments(tc.task)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("JoinedArguments(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package clients
+
+import (
+	"sort"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+)
+
+const (
+	AppStatusValid = "valid"
+
+	// AppStatusUnregistered is reported for apps, that are not registered
+	AppStatusUnregistered = "unregistered"
+)
+
+// ValidationResponse is returned by the /streams/validation/{name} and
+// /tasks/validation/{name} endpoints
+type ValidationResponse struct {
+	AppName      string            `json:"appName"`
+	Dsl          string            `json:"dsl"`
+	Description  string            `json:"description"`
+	AppsStatuses map[string]string `json:"appsStatuses"`
+}
+
+func (v *ValidationResponse) IsValid() bool {
+	return AppStatusesValid(v.AppsStatuses)
+}
+
+// ValidationError is returned if the definition can not be parsed or
+// at least one app of the definition is invalid
+type ValidationError struct {
+	Name        string
+	AppStatuses map[string]string
+	Cause       error
+}
+
+func (e *ValidationError) Error() string {
+	return "definition '" + e.Name + "' is invalid: " + e.message()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Cause
+}
+
+func (e *ValidationError) message() string {
+	if e.Cause != nil {
+		return e.Cause.Error()
+	}
+	return FormatAppStatuses(e.AppStatuses)
+}
+
+// Condition returns the Validated condition describing the error
+func (e *ValidationError) Condition() xpv1.Condition {
+	return core.Invalid().WithMessage(e.message())
+}
+
+func AppStatusesValid(appStatuses map[string]string) bool {
+	for _, status := range appStatuses {
+		if status != AppStatusValid {
+			return false
+		}
+	}
+	return true
+}
+
+// FormatAppStatuses renders the app statuses sorted by app, i.e.
+// "sink:log is valid, source:time is invalid"
+func FormatAppStatuses(appStatuses map[string]string) string {
+	apps := make([]string, 0, len(appStatuses))
+	for app := range appStatuses {
+		apps = append(apps, app)
+	}
+	sort.Strings(apps)
+
+	messages := make([]string, 0, len(apps))
+	for _, app := range apps {
+		messages = append(messages, app+" is "+appStatuses[app])
+	}
+	return strings.Join(messages, ", ")
+}
+
+// ValidationCondition maps the app statuses to the Validated condition
+func ValidationCondition(appStatuses map[string]string) xpv1.Condition {
+	if AppStatusesValid(appStatuses) {
+		return core.Valid().WithMessage(FormatAppStatuses(appStatuses))
+	}
+	return core.Invalid().WithMessage(FormatAppStatuses(appStatuses))
+}
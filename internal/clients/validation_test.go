@@ -0,0 +1,43 @@
+package clients
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestValidationCondition(t *testing.T) {
+	cases := map[string]struct {
+		appStatuses map[string]string
+		status      corev1.ConditionStatus
+		message     string
+	}{
+		"AllValid": {
+			appStatuses: map[string]string{"source:time": "valid", "sink:log": "valid"},
+			status:      corev1.ConditionTrue,
+			message:     "sink:log is valid, source:time is valid",
+		},
+		"OneInvalid": {
+			appStatuses: map[string]string{"source:time": "valid", "sink:log": "invalid"},
+			status:      corev1.ConditionFalse,
+			message:     "sink:log is invalid, source:time is valid",
+		},
+		"NoApps": {
+			appStatuses: map[string]string{},
+			status:      corev1.ConditionTrue,
+			message:     "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			condition := ValidationCondition(tc.appStatuses)
+			if condition.Status != tc.status {
+				t.Errorf("expected status %s, got %s", tc.status, condition.Status)
+			}
+			if condition.Message != tc.message {
+				t.Errorf("expected message '%s', got '%s'", tc.message, condition.Message)
+			}
+		})
+	}
+}
@@ -24,6 +24,8 @@ import (
 	"github.com/denniskniep/provider-springclouddataflow/internal/controller/config"
 	"github.com/denniskniep/provider-springclouddataflow/internal/controller/stream"
 	"github.com/denniskniep/provider-springclouddataflow/internal/controller/taskdefinition"
+	"github.com/denniskniep/provider-springclouddataflow/internal/controller/taskexecution"
+	"github.com/denniskniep/provider-springclouddataflow/internal/controller/taskexecutionretention"
 	"github.com/denniskniep/provider-springclouddataflow/internal/controller/taskschedule"
 )
 
@@ -35,6 +37,8 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		application.Setup,
 		taskdefinition.Setup,
 		taskschedule.Setup,
+		taskexecution.Setup,
+		taskexecutionretention.Setup,
 		stream.Setup,
 	} {
 		if err := setup(mgr, o); err != nil {
@@ -0,0 +1,141 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients/stream"
+)
+
+const (
+	errApplyActuator = "failed to apply actuator settings to instances: %s"
+
+	diffActuator = "actuator settings not applied to %d instances"
+
+	instanceStateDeployed = "deployed"
+)
+
+// actuatorInstance is a running instance of an app with actuator settings
+type actuatorInstance struct {
+	deploymentId string
+	settings     *v1alpha1.StreamAppActuator
+	hash         string
+	result       *v1alpha1.StreamActuatorInstance
+}
+
+// actuatorInstances returns all running instances of the apps with actuator
+// settings. The recorded results are carried over, so that instances added
+// by scaling are the only ones without an applied hash.
+func (c *external) actuatorInstances(ctx context.Context, cr *v1alpha1.Stream) ([]actuatorInstance, error) {
+	if len(cr.Spec.ForProvider.Actuator) == 0 || !deployed(cr) {
+		return nil, nil
+	}
+
+	apps, err := c.service.RuntimeApps(ctx, cr.Spec.ForProvider.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := map[string]v1alpha1.StreamActuatorInstance{}
+	for _, result := range cr.Status.AtProvider.ActuatorInstances {
+		recorded[result.App+"/"+result.Instance] = result
+	}
+
+	var instances []actuatorInstance
+	for _, app := range apps {
+		settings, ok := cr.Spec.ForProvider.Actuator[app.Name]
+		if !ok {
+			continue
+		}
+
+		for _, instance := range app.Instances.Embedded.Instances {
+			if instance.State != instanceStateDeployed {
+				continue
+			}
+
+			result, ok := recorded[app.Name+"/"+instance.InstanceId]
+			if !ok {
+				result = v1alpha1.StreamActuatorInstance{App: app.Name, Instance: instance.InstanceId}
+			}
+
+			instances = append(instances, actuatorInstance{
+				deploymentId: app.DeploymentId,
+				settings:     &settings,
+				hash:         stream.HashActuator(&settings),
+				result:       &result,
+			})
+		}
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].result.App+"/"+instances[i].result.Instance < instances[j].result.App+"/"+instances[j].result.Instance
+	})
+	return instances, nil
+}
+
+// observeActuator records the results of the running instances and reports
+// the instances, whose settings are not applied
+func (c *external) observeActuator(ctx context.Context, cr *v1alpha1.Stream) (string, error) {
+	instances, err := c.actuatorInstances(ctx, cr)
+	if err != nil {
+		return "", err
+	}
+
+	pending := 0
+	for _, instance := range instances {
+		if instance.result.AppliedHash != instance.hash {
+			pending++
+		}
+	}
+	cr.Status.AtProvider.ActuatorInstances = actuatorResults(instances)
+
+	if pending > 0 {
+		return fmt.Sprintf(diffActuator, pending), nil
+	}
+	return "", nil
+}
+
+// applyActuator applies the settings to all running instances, which did
+// not apply them yet, and records the result per instance
+func (c *external) applyActuator(ctx context.Context, cr *v1alpha1.Stream) error {
+	instances, err := c.actuatorInstances(ctx, cr)
+	if err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, instance := range instances {
+		if instance.result.AppliedHash == instance.hash {
+			continue
+		}
+
+		err := c.service.ApplyActuator(ctx, instance.deploymentId, instance.result.Instance, instance.settings)
+		if err != nil {
+			instance.result.Error = err.Error()
+			failed = append(failed, instance.result.Instance)
+			continue
+		}
+
+		instance.result.AppliedHash = instance.hash
+		instance.result.Error = ""
+	}
+	cr.Status.AtProvider.ActuatorInstances = actuatorResults(instances)
+
+	if len(failed) > 0 {
+		return errors.Errorf(errApplyActuator, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func actuatorResults(instances []actuatorInstance) []v1alpha1.StreamActuatorInstance {
+	var results []v1alpha1.StreamActuatorInstance
+	for _, instance := range instances {
+		results = append(results, *instance.result)
+	}
+	return results
+}
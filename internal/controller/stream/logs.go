@@ -0,0 +1,77 @@
+package stream
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+
+	"github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients/stream"
+)
+
+const (
+	reasonFailedAppLogs = "FailedAppLogs"
+	msgFailedAppLogs    = "App '%s' is %s:\n%s"
+	msgNoLogs           = "no logs available"
+	errCaptureLogs      = "Cannot capture logs of failing apps"
+
+	// Logs are captured at most once per interval and bounded in size, so
+	// that a failing stream does not flood etcd with events and status
+	logCaptureInterval = 10 * time.Minute
+	maxLogApps         = 5
+	maxLogLines        = 50
+	maxLogBytes        = 1024
+)
+
+// captureFailedAppLogs records the tail of the logs of all failing apps in
+// the status and in a Warning event, while the deployment is failed or
+// partial. The logs are cleared, once the deployment recovered.
+func (c *external) captureFailedAppLogs(ctx context.Context, cr *v1alpha1.Stream) {
+	status := &cr.Status.AtProvider
+	if status.Status != stream.StatusFailed && status.Status != stream.StatusPartial {
+		status.FailedAppLogs = nil
+		status.FailedAppLogsTime = nil
+		return
+	}
+
+	if status.FailedAppLogsTime != nil && time.Since(status.FailedAppLogsTime.Time) < logCaptureInterval {
+		return
+	}
+
+	// Logs are diagnostics only, therefore failures do not fail the observation
+	apps, err := c.service.RuntimeApps(ctx, cr.Spec.ForProvider.Name)
+	if err != nil {
+		c.logger.Info(errCaptureLogs, "stream", cr.Spec.ForProvider.Name, "error", err)
+		return
+	}
+
+	logs, err := c.service.Logs(ctx, cr.Spec.ForProvider.Name)
+	if err != nil {
+		c.logger.Info(errCaptureLogs, "stream", cr.Spec.ForProvider.Name, "error", err)
+		return
+	}
+
+	tails := map[string]string{}
+	for _, app := range apps {
+		if !app.Failing() || len(tails) >= maxLogApps {
+			continue
+		}
+
+		tail := stream.TailLog(logs[app.DeploymentId], maxLogLines, maxLogBytes)
+		if tail == "" {
+			tail = msgNoLogs
+		}
+
+		tails[app.DeploymentId] = tail
+		c.recorder.Event(cr, event.Warning(reasonFailedAppLogs, errors.Errorf(msgFailedAppLogs, app.DeploymentId, app.State, tail)))
+	}
+
+	now := metav1.Now()
+	status.FailedAppLogs = tails
+	status.FailedAppLogsTime = &now
+}
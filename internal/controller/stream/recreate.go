@@ -0,0 +1,54 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+
+	"github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients/stream"
+	"github.com/denniskniep/provider-springclouddataflow/internal/controllersdk"
+)
+
+const (
+	reasonRecreated      = "Recreated"
+	reasonRecreateFailed = "RecreateFailed"
+	msgRecreated         = "Recreated stream '%s', as its definition changed"
+)
+
+// recreate replaces the stream, whose definition, sensitive arguments or
+// description changed, and deploys it with the resolved properties of the given hash.
+// Invalid definitions are reported in the Validated condition.
+func (c *external) recreate(ctx context.Context, cr *v1alpha1.Stream, properties map[string]string, hash string) error {
+	err := c.service.Recreate(ctx, &cr.Spec.ForProvider, &cr.Status.AtProvider, properties)
+
+	var validationErr *clients.ValidationError
+	if errors.As(err, &validationErr) {
+		cr.SetConditions(validationErr.Condition())
+	}
+
+	if err != nil {
+		c.recorder.Event(cr, event.Warning(reasonRecreateFailed, err))
+		return err
+	}
+
+	// The versions of the new deployment are recorded by the next observe
+	cr.Status.AtProvider.DeploymentPropertiesHash = hash
+	cr.Status.AtProvider.SensitiveArgumentsHash = stream.SensitiveArgumentsHash(string(cr.GetUID()), &cr.Spec.ForProvider)
+	cr.Status.AtProvider.AppVersions = nil
+	cr.Status.AtProvider.ActuatorInstances = nil
+
+	c.recorder.Event(cr, event.Normal(reasonRecreated, fmt.Sprintf(msgRecreated, cr.Spec.ForProvider.Name)))
+	return nil
+}
+
+// sensitiveArgumentsChanged reports whether the sensitive arguments differ
+// from those the definition was created with, as the server masks them
+func sensitiveArgumentsChanged(cr *v1alpha1.Stream) bool {
+	hash := stream.SensitiveArgumentsHash(string(cr.GetUID()), &cr.Spec.ForProvider)
+	return !controllersdk.HashUpToDate(cr, hashSensitiveArguments, &cr.Status.AtProvider.SensitiveArgumentsHash, hash)
+}
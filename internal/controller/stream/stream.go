@@ -2,56 +2,325 @@ package stream
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 
 	"github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
 	"github.com/denniskniep/provider-springclouddataflow/internal/clients"
 	"github.com/denniskniep/provider-springclouddataflow/internal/clients/stream"
 	"github.com/denniskniep/provider-springclouddataflow/internal/controllersdk"
+	"github.com/denniskniep/provider-springclouddataflow/internal/dsl"
 )
 
+type genericService = clients.Service[*v1alpha1.Stream, v1alpha1.StreamParameters, v1alpha1.StreamObservation, stream.StreamCompare]
+
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	service clients.Service[*v1alpha1.Stream, v1alpha1.StreamParameters, v1alpha1.StreamObservation, stream.StreamCompare]
-	logger  logging.Logger
+	service  *stream.StreamService
+	kube     client.Client
+	logger   logging.Logger
+	recorder event.Recorder
 }
 
+const (
+	errNotStream           = "managed resource is not a Stream custom resource"
+	errListStreams         = "failed to list Streams"
+	errUpdateStream        = "failed to update stream deployment"
+	errDescribeTapped      = "failed to describe tapped stream"
+	errDescribeDeployment  = "failed to describe stream deployment"
+	errTappedStreamMissing = "tapped stream '%s' does not exist yet"
+
+	diffDeploymentProperties = "deployment properties changed"
+	diffSensitiveArguments   = "sensitive arguments changed"
+	diffPlatform             = "deployed to platform '%s' instead of '%s'"
+	diffAppVersions          = "default app versions changed"
+
+	// hashDeploymentProperties and hashSensitiveArguments identify the hashes
+	// of the resolved deployment properties and of the sensitive arguments,
+	// see controllersdk.HashUpToDate
+	hashDeploymentProperties = "deployment-properties"
+	hashSensitiveArguments   = "sensitive-arguments"
+)
+
 func newExternalClient[R resource.Managed](conn *controllersdk.Connector[R], creds []byte) (managed.ExternalClient, error) {
-	streamService, err := stream.NewStreamService(creds)
+	streamService, err := stream.NewStreamService(creds, conn.Kube)
 	if err != nil {
 		return nil, err
 	}
 
 	return &external{
-		service: streamService,
-		logger:  conn.Logger,
+		service:  streamService,
+		kube:     conn.Kube,
+		logger:   conn.Logger,
+		recorder: conn.Recorder,
 	}, nil
 }
 
 func Setup(mgr ctrl.Manager, o controller.Options) error {
-	return controllersdk.Setup(v1alpha1.StreamGroupVersionKind, &v1alpha1.Stream{}, mgr, o, newExternalClient[*v1alpha1.Stream])
+	watches := controllersdk.PropertySourceWatches(mgr.GetClient(),
+		func() client.ObjectList { return &v1alpha1.StreamList{} },
+		func(cr *v1alpha1.Stream) []v1alpha1.Property { return cr.Spec.ForProvider.DeploymentProperties })
+	watches = append(watches, defaultVersionWatch(mgr.GetClient()))
+
+	return controllersdk.Setup(v1alpha1.StreamGroupVersionKind, &v1alpha1.Stream{}, mgr, o, newExternalClient[*v1alpha1.Stream], watches...)
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	return controllersdk.Observe(ctx, c.logger, c.service, mg)
+	observation, err := controllersdk.Observe(ctx, c.logger, genericService(c.service), mg)
+	if err != nil || meta.WasDeleted(mg) {
+		return observation, err
+	}
+
+	if !observation.ResourceExists {
+		err = c.service.Preflight(ctx, &mg.(*v1alpha1.Stream).Spec.ForProvider)
+
+		var validationErr *clients.ValidationError
+		if errors.As(err, &validationErr) {
+			return controllersdk.Invalid(mg, validationErr.Condition(), err)
+		}
+		return observation, err
+	}
+
+	cr := mg.(*v1alpha1.Stream)
+	c.describeDeployment(ctx, cr)
+	c.captureFailedAppLogs(ctx, cr)
+
+	if !observation.ResourceUpToDate {
+		return observation, nil
+	}
+
+	if sensitiveArgumentsChanged(cr) {
+		observation.ResourceUpToDate = false
+		observation.Diff = diffSensitiveArguments
+		return observation, nil
+	}
+
+	if platformDrifted(cr) {
+		observation.ResourceUpToDate = false
+		observation.Diff = fmt.Sprintf(diffPlatform, cr.Status.AtProvider.Platform, cr.Spec.ForProvider.Platform)
+		return observation, nil
+	}
+
+	if deployed(cr) && cr.Spec.ForProvider.FollowDefaultVersions {
+		versions, err := c.service.DefaultAppVersions(ctx, &cr.Spec.ForProvider)
+		if err != nil {
+			return observation, err
+		}
+
+		if cr.Status.AtProvider.AppVersions == nil {
+			// The stream was deployed with the default versions
+			cr.Status.AtProvider.AppVersions = versions
+		} else if len(changedVersions(cr.Status.AtProvider.AppVersions, versions)) > 0 {
+			observation.ResourceUpToDate = false
+			observation.Diff = diffAppVersions
+			return observation, nil
+		}
+	}
+
+	properties, err := c.service.DeploymentProperties(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return observation, err
+	}
+
+	hash := clients.HashProperties(string(cr.GetUID()), properties)
+	if !controllersdk.HashUpToDate(cr, hashDeploymentProperties, &cr.Status.AtProvider.DeploymentPropertiesHash, hash) {
+		observation.ResourceUpToDate = false
+		observation.Diff = diffDeploymentProperties
+		return observation, nil
+	}
+
+	diff, err := c.observeActuator(ctx, cr)
+	if err != nil {
+		return observation, err
+	}
+
+	if diff != "" {
+		observation.ResourceUpToDate = false
+		observation.Diff = diff
+	}
+
+	return observation, nil
+}
+
+// describeDeployment describes the app statuses and the platform, once the
+// definition or the deployment changed, and reports the Validated condition.
+// Failures keep the stream observable, they are retried by the next observe.
+func (c *external) describeDeployment(ctx context.Context, cr *v1alpha1.Stream) {
+	if cr.Status.AtProvider.AppStatuses == nil {
+		err := c.service.DescribeDeployment(ctx, &cr.Status.AtProvider)
+		if err != nil {
+			c.logger.Info(errDescribeDeployment, "stream", cr.Spec.ForProvider.Name, "error", err)
+			return
+		}
+	}
+	cr.SetConditions(clients.ValidationCondition(cr.Status.AtProvider.AppStatuses))
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	return controllersdk.Create(ctx, c.logger, c.service, mg)
+	cr, ok := mg.(*v1alpha1.Stream)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotStream)
+	}
+
+	// A tap on a not yet existing stream would be created as a plain named
+	// destination, therefore wait for the tapped stream
+	err := c.awaitTappedStream(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	properties, err := c.service.DeploymentProperties(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	err = c.service.CreateWithProperties(ctx, &cr.Spec.ForProvider, properties)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	meta.SetExternalName(cr, cr.Spec.ForProvider.Name)
+	controllersdk.RecordCreatedHash(cr, hashDeploymentProperties, clients.HashProperties(string(cr.GetUID()), properties))
+	controllersdk.RecordCreatedHash(cr, hashSensitiveArguments, stream.SensitiveArgumentsHash(string(cr.GetUID()), &cr.Spec.ForProvider))
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) awaitTappedStream(ctx context.Context, cr *v1alpha1.Stream) error {
+	tappedStream, err := c.tappedStream(ctx, cr)
+	if err != nil || tappedStream == "" {
+		return err
+	}
+
+	observed, err := c.service.Describe(ctx, &v1alpha1.StreamParameters{Name: tappedStream})
+	if err != nil {
+		return errors.Wrap(err, errDescribeTapped)
+	}
+
+	if observed == nil {
+		return errors.Errorf(errTappedStreamMissing, tappedStream)
+	}
+
+	return nil
+}
+
+// tappedStream returns the name of the tapped stream, which is either
+// referenced explicitly or tapped within the definition and managed by
+// another Stream
+func (c *external) tappedStream(ctx context.Context, cr *v1alpha1.Stream) (string, error) {
+	if cr.Spec.ForProvider.TappedStreamName != nil {
+		return *cr.Spec.ForProvider.TappedStreamName, nil
+	}
+
+	// Invalid definitions are reported by the server on create
+	node, err := dsl.ParseStream(stream.Definition(&cr.Spec.ForProvider))
+	if err != nil {
+		return "", nil
+	}
+
+	candidate, ok := node.TappedStream()
+	if !ok {
+		return "", nil
+	}
+
+	streams := &v1alpha1.StreamList{}
+	if err := c.kube.List(ctx, streams); err != nil {
+		return "", errors.Wrap(err, errListStreams)
+	}
+
+	for _, s := range streams.Items {
+		if s.Spec.ForProvider.Name == candidate {
+			return candidate, nil
+		}
+	}
+
+	// Not a tap, but a named destination containing a dot
+	return "", nil
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	return controllersdk.Update(ctx, c.logger, c.service, mg)
+	cr, ok := mg.(*v1alpha1.Stream)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotStream)
+	}
+
+	properties, err := c.service.DeploymentProperties(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	hash := clients.HashProperties(string(cr.GetUID()), properties)
+
+	if c.service.DefinitionChanged(&cr.Spec.ForProvider, &cr.Status.AtProvider) || sensitiveArgumentsChanged(cr) {
+		return managed.ExternalUpdate{}, c.recreate(ctx, cr, properties, hash)
+	}
+
+	var versions, changed map[string]string
+	if deployed(cr) && cr.Spec.ForProvider.FollowDefaultVersions {
+		versions, err = c.service.DefaultAppVersions(ctx, &cr.Spec.ForProvider)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+
+		changed = changedVersions(cr.Status.AtProvider.AppVersions, versions)
+		for app, version := range changed {
+			properties[stream.VersionProperty(app)] = version
+		}
+	}
+
+	switch {
+	case platformDrifted(cr):
+		err = c.service.ValidatePlatform(ctx, cr.Spec.ForProvider.Platform)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+
+		// Skipper can not move a release to another platform
+		err = c.service.Redeploy(ctx, &cr.Spec.ForProvider, properties)
+	case hash != cr.Status.AtProvider.DeploymentPropertiesHash || len(changed) > 0:
+		err = c.service.UpdateDeployment(ctx, &cr.Spec.ForProvider, properties)
+	}
+
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateStream)
+	}
+
+	cr.Status.AtProvider.DeploymentPropertiesHash = hash
+	if versions != nil {
+		cr.Status.AtProvider.AppVersions = versions
+	}
+	if len(changed) > 0 {
+		c.recorder.Event(cr, event.Normal(reasonUpdatedAppVersions, fmt.Sprintf(msgUpdatedAppVersions, clients.JoinProperties(changed))))
+	}
+
+	// Instances, that are not running yet, are applied with the next update
+	return managed.ExternalUpdate{}, c.applyActuator(ctx, cr)
+}
+
+// deployed reports whether the stream was observed with a deployment
+func deployed(cr *v1alpha1.Stream) bool {
+	status := cr.Status.AtProvider.Status
+	return status != "" && status != stream.StatusUndeployed
+}
+
+// platformDrifted reports whether the stream is deployed to another platform
+// than the desired one. Undeployed streams have no platform.
+func platformDrifted(cr *v1alpha1.Stream) bool {
+	observed := cr.Status.AtProvider.Platform
+	return observed != "" && observed != cr.Spec.ForProvider.Platform
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
-	return controllersdk.Delete(ctx, c.logger, c.service, mg)
+	return controllersdk.Delete(ctx, c.logger, genericService(c.service), mg)
 }
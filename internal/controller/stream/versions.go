@@ -0,0 +1,86 @@
+package stream
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients/stream"
+	"github.com/denniskniep/provider-springclouddataflow/internal/controllersdk"
+	"github.com/denniskniep/provider-springclouddataflow/internal/dsl"
+)
+
+const (
+	reasonUpdatedAppVersions = "UpdatedAppVersions"
+	msgUpdatedAppVersions    = "Updated apps to their default versions: %s"
+)
+
+// defaultVersionWatch reconciles all Streams following default versions,
+// that use an Application, once it became the default version
+func defaultVersionWatch(kube client.Reader) controllersdk.Watch {
+	return controllersdk.Watch{
+		Object: &v1alpha1.Application{},
+		Map: func(ctx context.Context, obj client.Object) []reconcile.Request {
+			app, ok := obj.(*v1alpha1.Application)
+			if !ok {
+				return nil
+			}
+
+			streams := &v1alpha1.StreamList{}
+			if err := kube.List(ctx, streams); err != nil {
+				// The streams are reconciled with the next poll anyway
+				return nil
+			}
+
+			var requests []reconcile.Request
+			for _, s := range streams.Items {
+				if s.Spec.ForProvider.FollowDefaultVersions && usesApp(&s, app.Spec.ForProvider.Name) {
+					requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: s.GetName()}})
+				}
+			}
+			return requests
+		},
+		Predicates: []predicate.Predicate{predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				oldApp, okOld := e.ObjectOld.(*v1alpha1.Application)
+				newApp, okNew := e.ObjectNew.(*v1alpha1.Application)
+				return okOld && okNew && newApp.Status.AtProvider.DefaultVersion && !oldApp.Status.AtProvider.DefaultVersion
+			},
+			CreateFunc:  func(e event.CreateEvent) bool { return false },
+			DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+			GenericFunc: func(e event.GenericEvent) bool { return false },
+		}},
+	}
+}
+
+func usesApp(cr *v1alpha1.Stream, name string) bool {
+	node, err := dsl.ParseStream(stream.Definition(&cr.Spec.ForProvider))
+	if err != nil {
+		return false
+	}
+
+	for _, appName := range node.AppNames() {
+		if appName == name {
+			return true
+		}
+	}
+	return false
+}
+
+// changedVersions returns the apps, whose version differs from the recorded
+// version, with their new version
+func changedVersions(recorded map[string]string, versions map[string]string) map[string]string {
+	changed := map[string]string{}
+	for app, version := range versions {
+		if recorded[app] != version {
+			changed[app] = version
+		}
+	}
+	return changed
+}
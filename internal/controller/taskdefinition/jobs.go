@@ -0,0 +1,75 @@
+package taskdefinition
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+
+	"github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients/taskdefinition"
+)
+
+const (
+	reasonRestartedJob    = "RestartedJob"
+	reasonRestartJobError = "RestartJobFailed"
+	msgRestartedJob       = "Restarted failed execution %d of job '%s', attempt %d"
+	msgRestartJobError    = "Cannot restart failed execution %d of job '%s', attempt %d: %s"
+
+	maxRecordedRestarts = 50
+
+	diffRestartJobs = "failed job executions are restarted"
+)
+
+// restartCandidates returns the failed job executions to restart according
+// to the restartFailedJobs policy
+func restartCandidates(cr *v1alpha1.TaskDefinition) []v1alpha1.JobRestart {
+	spec := &cr.Spec.ForProvider
+	if spec.BatchJob == nil || spec.BatchJob.RestartFailedJobs == nil {
+		return nil
+	}
+
+	status := &cr.Status.AtProvider
+	return taskdefinition.RestartCandidates(status.JobExecutions, status.JobRestarts, spec.BatchJob.RestartFailedJobs)
+}
+
+// restartFailedJobs restarts the failed job executions and records every
+// attempt in the status. It runs during update, so that the attempts are
+// persisted before the next observe. Once restarted, a failed execution is
+// no longer the latest of its job instance and is not restarted again, even
+// if the attempt was not recorded. Failed restarts are reported, but do not
+// fail the update.
+func (c *external) restartFailedJobs(ctx context.Context, cr *v1alpha1.TaskDefinition) {
+	spec := &cr.Spec.ForProvider
+	status := &cr.Status.AtProvider
+	candidates := restartCandidates(cr)
+
+	jobName := taskdefinition.JobName(spec)
+	for _, restart := range candidates {
+		execution := jobExecution(status.JobExecutions, restart.ExecutionID)
+		err := c.service.RestartJob(ctx, execution)
+
+		restart.Time = metav1.Now()
+		if err != nil {
+			restart.Error = err.Error()
+			c.recorder.Event(cr, event.Warning(reasonRestartJobError, fmt.Errorf(msgRestartJobError, restart.ExecutionID, jobName, restart.Attempt, err.Error())))
+		} else {
+			c.recorder.Event(cr, event.Normal(reasonRestartedJob, fmt.Sprintf(msgRestartedJob, restart.ExecutionID, jobName, restart.Attempt)))
+		}
+
+		status.JobRestarts = append([]v1alpha1.JobRestart{restart}, status.JobRestarts...)
+	}
+
+	status.JobRestarts = taskdefinition.TrimRestarts(status.JobRestarts, status.JobExecutions, maxRecordedRestarts)
+}
+
+func jobExecution(executions []v1alpha1.JobExecution, id int64) *v1alpha1.JobExecution {
+	for i := range executions {
+		if executions[i].ExecutionID == id {
+			return &executions[i]
+		}
+	}
+	return nil
+}
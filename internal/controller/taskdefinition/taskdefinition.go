@@ -2,14 +2,20 @@ package taskdefinition
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 
 	"github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
 	"github.com/denniskniep/provider-springclouddataflow/internal/clients"
@@ -17,13 +23,33 @@ import (
 	"github.com/denniskniep/provider-springclouddataflow/internal/controllersdk"
 )
 
+type genericService = clients.Service[*v1alpha1.TaskDefinition, v1alpha1.TaskDefinitionParameters, v1alpha1.TaskDefinitionObservation, taskdefinition.TaskDefinitionCompare]
+
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	service clients.Service[*v1alpha1.TaskDefinition, v1alpha1.TaskDefinitionParameters, v1alpha1.TaskDefinitionObservation, taskdefinition.TaskDefinitionCompare]
-	logger  logging.Logger
+	service  *taskdefinition.TaskDefinitionService
+	kube     client.Reader
+	logger   logging.Logger
+	recorder event.Recorder
 }
 
+const (
+	errNotTaskDefinition = "managed resource is not a TaskDefinition custom resource"
+	errDelete            = "cannot delete TaskDefinition"
+
+	reasonDeleted = "Deleted"
+	msgDeleted    = "Deleted task definition '%s'"
+	msgCleanedUp  = ", cleaned up its executions"
+	msgNotCleaned = ", its executions were kept"
+
+	diffSensitiveArguments = "sensitive arguments changed"
+
+	// hashSensitiveArguments identifies the hash of the sensitive arguments,
+	// see controllersdk.HashUpToDate
+	hashSensitiveArguments = "sensitive-arguments"
+)
+
 func newExternalClient[R resource.Managed](conn *controllersdk.Connector[R], creds []byte) (managed.ExternalClient, error) {
 	taskDefinitionService, err := taskdefinition.NewTaskDefinitionService(creds)
 	if err != nil {
@@ -31,8 +57,10 @@ func newExternalClient[R resource.Managed](conn *controllersdk.Connector[R], cre
 	}
 
 	return &external{
-		service: taskDefinitionService,
-		logger:  conn.Logger,
+		service:  taskDefinitionService,
+		kube:     conn.Kube,
+		logger:   conn.Logger,
+		recorder: conn.Recorder,
 	}, nil
 }
 
@@ -41,17 +69,76 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	return controllersdk.Observe(ctx, c.logger, c.service, mg)
+	cr, ok := mg.(*v1alpha1.TaskDefinition)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTaskDefinition)
+	}
+
+	if _, err := taskdefinition.Definition(&cr.Spec.ForProvider); err != nil && !meta.WasDeleted(cr) {
+		return controllersdk.Invalid(cr, v1alpha1.Invalid().WithMessage(err.Error()), err)
+	}
+
+	observation, err := controllersdk.Observe(ctx, c.logger, genericService(c.service), mg)
+	if err != nil || !observation.ResourceExists {
+		return observation, err
+	}
+
+	cr.SetConditions(clients.ValidationCondition(cr.Status.AtProvider.AppStatuses))
+
+	if observation.ResourceUpToDate && sensitiveArgumentsChanged(cr) {
+		observation.ResourceUpToDate = false
+		observation.Diff = diffSensitiveArguments
+	}
+
+	// Failed jobs are restarted during update, which persists the attempts
+	if observation.ResourceUpToDate && len(restartCandidates(cr)) > 0 {
+		observation.ResourceUpToDate = false
+		observation.Diff = diffRestartJobs
+	}
+	return observation, nil
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	return controllersdk.Create(ctx, c.logger, c.service, mg)
-}
+	cr, ok := mg.(*v1alpha1.TaskDefinition)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTaskDefinition)
+	}
 
-func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	return controllersdk.Update(ctx, c.logger, c.service, mg)
+	creation, err := controllersdk.Create(ctx, c.logger, genericService(c.service), mg)
+	if err == nil {
+		controllersdk.RecordCreatedHash(cr, hashSensitiveArguments, taskdefinition.SensitiveArgumentsHash(string(cr.GetUID()), &cr.Spec.ForProvider))
+	}
+	return creation, err
 }
 
+// Delete deletes the definition according to its cleanupOnDelete policy and
+// reports stopped and cleaned up executions in an event
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
-	return controllersdk.Delete(ctx, c.logger, c.service, mg)
+	cr, ok := mg.(*v1alpha1.TaskDefinition)
+	if !ok {
+		return errors.New(errNotTaskDefinition)
+	}
+
+	result, err := c.service.DeleteWithCleanup(ctx, &cr.Spec.ForProvider)
+	if err != nil && result != nil && len(result.Stopped) > 0 {
+		// Returning an error requeues the delete, until the executions completed
+		cr.SetConditions(v1alpha1.WaitingForExecutions().WithMessage(err.Error()))
+		return err
+	}
+	if err != nil {
+		return errors.Wrap(err, errDelete)
+	}
+
+	if result.Existed {
+		c.recorder.Event(cr, event.Normal(reasonDeleted, deletedMessage(cr.Spec.ForProvider.Name, result)))
+	}
+	return nil
+}
+
+func deletedMessage(name string, result *taskdefinition.DeleteResult) string {
+	msg := fmt.Sprintf(msgDeleted, name)
+	if result.CleanedUp {
+		return msg + msgCleanedUp
+	}
+	return msg + msgNotCleaned
 }
@@ -0,0 +1,195 @@
+package taskdefinition
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients/taskdefinition"
+	"github.com/denniskniep/provider-springclouddataflow/internal/controllersdk"
+)
+
+const (
+	errWaiting  = "waiting for running executions %s of task '%s' to complete"
+	errRecreate = "cannot recreate TaskDefinition"
+	errMasked   = "sensitive arguments are masked by the server, the changed definition is not recreated, as the previous one could not be restored"
+
+	reasonRecreated          = "Recreated"
+	reasonRecreateFailed     = "RecreateFailed"
+	reasonRestored           = "Restored"
+	reasonRestoreFailed      = "RestoreFailed"
+	reasonSchedulesDeleted   = "SchedulesDeleted"
+	reasonScheduleNotManaged = "ScheduleNotRecreated"
+
+	msgRecreated          = "Recreated task definition '%s' to apply the changes"
+	msgRestored           = "Restored previous task definition '%s'"
+	msgSchedulesDeleted   = "Schedules %s were deleted with the definition, they are recreated by their TaskSchedules"
+	msgScheduleNotManaged = "Schedule '%s' on platform '%s' was deleted with the definition and is not managed by a TaskSchedule, it has to be recreated manually"
+	msgScheduleLookup     = "cannot look up TaskSchedules for the deleted schedules: %s"
+)
+
+// Update restarts failed jobs, if only those are pending. Otherwise it
+// deletes and recreates the definition, as definitions can not be updated by
+// the server. The new definition is checked and it waits until no executions
+// are running before. If creating fails, the previous definition is
+// restored. Definitions, whose masked sensitive arguments changed, can not
+// be restored and are not recreated. The server deletes the schedules of the
+// task with the definition, they are recreated by their TaskSchedules or
+// reported.
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.TaskDefinition)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTaskDefinition)
+	}
+	spec := &cr.Spec.ForProvider
+
+	sensitiveChanged := sensitiveArgumentsChanged(cr)
+	if !c.service.DefinitionChanged(spec, &cr.Status.AtProvider) && !sensitiveChanged {
+		c.restartFailedJobs(ctx, cr)
+		return managed.ExternalUpdate{}, nil
+	}
+
+	err := c.service.Preflight(ctx, spec)
+
+	var validationErr *clients.ValidationError
+	if errors.As(err, &validationErr) {
+		cr.SetConditions(validationErr.Condition())
+	}
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errRecreate)
+	}
+
+	// Masked values of the server are only known, if they were not changed
+	var applied map[string]string
+	if !sensitiveChanged {
+		applied = taskdefinition.SensitiveArguments(spec)
+	}
+
+	previous := taskdefinition.PreviousDefinition(spec, &cr.Status.AtProvider, applied)
+	if previous == nil {
+		err = errors.New(errMasked)
+		cr.SetConditions(v1alpha1.NotRestorable().WithMessage(err.Error()))
+		return managed.ExternalUpdate{}, err
+	}
+
+	running, err := c.service.RunningExecutions(ctx, spec.Name)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errRecreate)
+	}
+
+	// Returning an error requeues the update, until the executions completed
+	if len(running) > 0 {
+		ids := make([]string, 0, len(running))
+		for _, execution := range running {
+			ids = append(ids, strconv.FormatInt(execution.ExecutionId, 10))
+		}
+		err = errors.Errorf(errWaiting, strings.Join(ids, ", "), spec.Name)
+		cr.SetConditions(v1alpha1.WaitingForExecutions().WithMessage(err.Error()))
+		return managed.ExternalUpdate{}, err
+	}
+
+	schedules, err := c.service.Schedules(ctx, spec.Name)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errRecreate)
+	}
+
+	err = c.service.DeleteDefinition(ctx, spec.Name)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errRecreate)
+	}
+	c.reportDeletedSchedules(ctx, cr, schedules)
+
+	err = c.service.Create(ctx, spec)
+	if err == nil {
+		cr.Status.AtProvider.SensitiveArgumentsHash = taskdefinition.SensitiveArgumentsHash(string(cr.GetUID()), spec)
+		c.recorder.Event(cr, event.Normal(reasonRecreated, fmt.Sprintf(msgRecreated, spec.Name)))
+		return managed.ExternalUpdate{}, nil
+	}
+	c.recorder.Event(cr, event.Warning(reasonRecreateFailed, err))
+
+	restoreErr := c.service.Create(ctx, previous)
+	if restoreErr != nil {
+		c.recorder.Event(cr, event.Warning(reasonRestoreFailed, restoreErr))
+		return managed.ExternalUpdate{}, errors.Wrap(err, errRecreate)
+	}
+
+	c.recorder.Event(cr, event.Normal(reasonRestored, fmt.Sprintf(msgRestored, spec.Name)))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errRecreate)
+}
+
+// reportDeletedSchedules records the deleted schedules with the TaskSchedules
+// managing them. Schedules without a TaskSchedule are reported in a warning,
+// as their arguments are not reported by the server and they can not be
+// recreated as they were.
+func (c *external) reportDeletedSchedules(ctx context.Context, cr *v1alpha1.TaskDefinition, schedules []taskdefinition.Schedule) {
+	cr.Status.AtProvider.DeletedSchedules = nil
+	if len(schedules) == 0 {
+		return
+	}
+
+	managedBy, err := c.taskSchedules(ctx, cr.Spec.ForProvider.Name)
+	if err != nil {
+		c.recorder.Event(cr, event.Warning(reasonSchedulesDeleted, errors.Errorf(msgScheduleLookup, err.Error())))
+	}
+
+	now := metav1.Now()
+	var recreated []string
+	for _, schedule := range schedules {
+		taskSchedule := managedBy[schedule.Name]
+		cr.Status.AtProvider.DeletedSchedules = append(cr.Status.AtProvider.DeletedSchedules, v1alpha1.DeletedSchedule{
+			ScheduleName:   schedule.Name,
+			Platform:       schedule.Platform,
+			CronExpression: schedule.CronExpression,
+			TaskSchedule:   taskSchedule,
+			Time:           now,
+		})
+
+		if taskSchedule == "" {
+			c.recorder.Event(cr, event.Warning(reasonScheduleNotManaged, errors.Errorf(msgScheduleNotManaged, schedule.Name, schedule.Platform)))
+			continue
+		}
+		recreated = append(recreated, "'"+schedule.Name+"'")
+	}
+
+	if len(recreated) > 0 {
+		c.recorder.Event(cr, event.Normal(reasonSchedulesDeleted, fmt.Sprintf(msgSchedulesDeleted, strings.Join(recreated, ", "))))
+	}
+}
+
+// taskSchedules returns the names of the TaskSchedules of the task keyed by
+// their schedule name. They recreate their schedules, once they observe them
+// missing.
+func (c *external) taskSchedules(ctx context.Context, name string) (map[string]string, error) {
+	list := &v1alpha1.TaskScheduleList{}
+	err := c.kube.List(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+
+	managedBy := map[string]string{}
+	for _, taskSchedule := range list.Items {
+		spec := taskSchedule.Spec.ForProvider
+		if spec.TaskDefinitionName != nil && *spec.TaskDefinitionName == name && !spec.Suspend {
+			managedBy[spec.ScheduleName] = taskSchedule.GetName()
+		}
+	}
+	return managedBy, nil
+}
+
+// sensitiveArgumentsChanged reports whether the sensitive arguments differ
+// from those the definition was created with, as the server masks them
+func sensitiveArgumentsChanged(cr *v1alpha1.TaskDefinition) bool {
+	hash := taskdefinition.SensitiveArgumentsHash(string(cr.GetUID()), &cr.Spec.ForProvider)
+	return !controllersdk.HashUpToDate(cr, hashSensitiveArguments, &cr.Status.AtProvider.SensitiveArgumentsHash, hash)
+}
@@ -0,0 +1,137 @@
+package taskexecution
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients/taskexecution"
+)
+
+const (
+	// annotationPendingLaunch marks a launch, whose execution id is not
+	// recorded yet. Its value is "<latest execution id before>/<launch hash>".
+	annotationPendingLaunch = "springclouddataflow.crossplane.io/pending-launch"
+
+	reasonAdopted = "Adopted"
+	msgAdopted    = "Adopted execution %d of task '%s', whose launch was not recorded"
+)
+
+// launchTask launches the task and records the id of the execution as
+// external name. The launch is marked as pending before, so that the
+// execution is adopted by the next observe instead of launching the task
+// again, if recording the id fails.
+func (c *external) launchTask(ctx context.Context, cr *v1alpha1.TaskExecution, properties map[string]string, hash string) (int64, error) {
+	name := *cr.Spec.ForProvider.TaskDefinitionName
+	after, err := c.service.LatestExecutionId(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+
+	err = c.updateAnnotations(ctx, cr, func(annotations map[string]string) {
+		annotations[annotationPendingLaunch] = strconv.FormatInt(after, 10) + "/" + hash
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, errMarkLaunch)
+	}
+
+	id, err := c.service.Launch(ctx, &cr.Spec.ForProvider, properties)
+	if err != nil {
+		// Nothing was launched, the marker is removed by the next observe
+		// otherwise
+		_ = c.updateAnnotations(ctx, cr, func(annotations map[string]string) {
+			delete(annotations, annotationPendingLaunch)
+		})
+		return 0, err
+	}
+	c.recorder.Event(cr, event.Normal(reasonLaunched, fmt.Sprintf(msgLaunched, name, id)))
+
+	err = c.updateAnnotations(ctx, cr, func(annotations map[string]string) {
+		annotations[meta.AnnotationKeyExternalName] = strconv.FormatInt(id, 10)
+		delete(annotations, annotationPendingLaunch)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, errRecordExecution)
+	}
+	return id, nil
+}
+
+// adoptPendingLaunch resolves a launch, whose execution id could not be
+// recorded. The first execution of the task launched after the marker was
+// set is adopted. Without such an execution the launch failed and only the
+// marker is removed.
+func (c *external) adoptPendingLaunch(ctx context.Context, cr *v1alpha1.TaskExecution) error {
+	value, ok := cr.GetAnnotations()[annotationPendingLaunch]
+	if !ok || cr.Spec.ForProvider.TaskDefinitionName == nil {
+		return nil
+	}
+
+	name := *cr.Spec.ForProvider.TaskDefinitionName
+	afterValue, hash, _ := strings.Cut(value, "/")
+	after, _ := strconv.ParseInt(afterValue, 10, 64)
+
+	id, err := c.service.LaunchedAfter(ctx, name, after)
+	if err != nil {
+		return err
+	}
+
+	previous := cr.Status.AtProvider
+	err = c.updateAnnotations(ctx, cr, func(annotations map[string]string) {
+		delete(annotations, annotationPendingLaunch)
+		if id != nil {
+			annotations[meta.AnnotationKeyExternalName] = strconv.FormatInt(*id, 10)
+		}
+	})
+	if err != nil || id == nil {
+		return err
+	}
+
+	cr.Status.AtProvider = v1alpha1.TaskExecutionObservation{
+		ExecutionID: id,
+		LaunchHash:  hash,
+		History:     taskexecution.AppendHistory(previous.History, &previous, cr.Spec.ForProvider.HistoryLimit),
+	}
+	c.recorder.Event(cr, event.Normal(reasonAdopted, fmt.Sprintf(msgAdopted, *id, name)))
+	return nil
+}
+
+// updateAnnotations persists the annotations changed by mutate on the latest
+// version of the resource and retries on conflicts, like the critical
+// annotations of the managed reconciler. The status is kept, as it is only
+// persisted at the end of the reconcile.
+func (c *external) updateAnnotations(ctx context.Context, cr *v1alpha1.TaskExecution, mutate func(annotations map[string]string)) error {
+	status := cr.Status
+	defer func() { cr.Status = status }()
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &v1alpha1.TaskExecution{}
+		err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetName()}, latest)
+		if err != nil {
+			return err
+		}
+
+		annotations := latest.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		mutate(annotations)
+		latest.SetAnnotations(annotations)
+
+		err = c.kube.Update(ctx, latest)
+		if err != nil {
+			return err
+		}
+		latest.DeepCopyInto(cr)
+		return nil
+	})
+}
@@ -0,0 +1,248 @@
+package taskexecution
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients/taskexecution"
+	"github.com/denniskniep/provider-springclouddataflow/internal/controllersdk"
+)
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+// The external name of a TaskExecution is the id of its execution.
+type external struct {
+	service  *taskexecution.TaskExecutionService
+	kube     client.Client
+	logger   logging.Logger
+	recorder event.Recorder
+}
+
+const (
+	errNotTaskExecution = "managed resource is not a TaskExecution custom resource"
+	errExecutionId      = "external name '%s' is not a task execution id"
+	errNoTaskDefinition = "taskDefinitionName is not resolved"
+	errStillRunning     = "execution %d is still running and the concurrency policy forbids to launch the task again"
+	errRecordExecution  = "failed to record the id of the launched execution"
+	errMarkLaunch       = "failed to mark the launch as pending"
+	errAdoptLaunch      = "failed to adopt the execution of the pending launch"
+
+	diffLaunch = "arguments, properties or runId changed"
+
+	// hashLaunch identifies the hash of the launched parameters, see
+	// controllersdk.HashUpToDate
+	hashLaunch = "launch"
+
+	msgExecutionRemoved = "task execution %d does not exist anymore"
+
+	reasonLaunched = "Launched"
+	msgLaunched    = "Launched task '%s' as execution %d"
+
+	reasonStopped = "Stopped"
+	msgStopped    = "Stopped execution %d to launch the task again"
+)
+
+func newExternalClient[R resource.Managed](conn *controllersdk.Connector[R], creds []byte) (managed.ExternalClient, error) {
+	service, err := taskexecution.NewTaskExecutionService(creds, conn.Kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{
+		service:  service,
+		kube:     conn.Kube,
+		logger:   conn.Logger,
+		recorder: conn.Recorder,
+	}, nil
+}
+
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	return controllersdk.Setup(v1alpha1.TaskExecutionGroupVersionKind, &v1alpha1.TaskExecution{}, mgr, o, newExternalClient[*v1alpha1.TaskExecution])
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.TaskExecution)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTaskExecution)
+	}
+
+	err := c.adoptPendingLaunch(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errAdoptLaunch)
+	}
+
+	id, err := executionId(cr)
+	if err != nil || id == nil {
+		return managed.ExternalObservation{ResourceExists: false}, err
+	}
+
+	observed, err := c.service.Describe(ctx, *id)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	if meta.WasDeleted(cr) {
+		// Completed executions are kept as history of the task
+		return managed.ExternalObservation{ResourceExists: observed != nil && observed.Status == taskexecution.StatusRunning}, nil
+	}
+
+	if observed == nil {
+		// Relaunching would run the task again, therefore removed executions
+		// are reported instead
+		cr.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf(msgExecutionRemoved, *id)))
+	} else {
+		// The server reports neither the launch hash nor superseded executions
+		recorded := cr.Status.AtProvider
+		cr.Status.AtProvider = *observed
+		cr.Status.AtProvider.LaunchHash = recorded.LaunchHash
+		cr.Status.AtProvider.History = recorded.History
+		cr.SetConditions(taskexecution.Condition(observed))
+	}
+
+	properties, err := c.service.LaunchProperties(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	observation := managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}
+	hash := taskexecution.LaunchHash(string(cr.GetUID()), &cr.Spec.ForProvider, properties)
+	if !controllersdk.HashUpToDate(cr, hashLaunch, &cr.Status.AtProvider.LaunchHash, hash) {
+		observation.ResourceUpToDate = false
+		observation.Diff = diffLaunch
+	}
+
+	return observation, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.TaskExecution)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTaskExecution)
+	}
+
+	if cr.Spec.ForProvider.TaskDefinitionName == nil {
+		return managed.ExternalCreation{}, errors.New(errNoTaskDefinition)
+	}
+
+	properties, err := c.service.LaunchProperties(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	hash := taskexecution.LaunchHash(string(cr.GetUID()), &cr.Spec.ForProvider, properties)
+
+	err = c.service.CheckCapacity(ctx, cr.Spec.ForProvider.Platform)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	id, err := c.service.Launch(ctx, &cr.Spec.ForProvider, properties)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	meta.SetExternalName(cr, strconv.FormatInt(id, 10))
+	controllersdk.RecordCreatedHash(cr, hashLaunch, hash)
+	c.recorder.Event(cr, event.Normal(reasonLaunched, fmt.Sprintf(msgLaunched, *cr.Spec.ForProvider.TaskDefinitionName, id)))
+	return managed.ExternalCreation{}, nil
+}
+
+// Update launches the task again according to the concurrency policy and
+// records the superseded execution in the history
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.TaskExecution)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTaskExecution)
+	}
+
+	previous := cr.Status.AtProvider
+	if previous.Status == taskexecution.StatusRunning && previous.ExecutionID != nil {
+		switch cr.Spec.ForProvider.ConcurrencyPolicy {
+		case taskexecution.ConcurrencyAllow:
+		case taskexecution.ConcurrencyReplace:
+			err := c.service.Stop(ctx, *previous.ExecutionID, cr.Spec.ForProvider.Platform)
+			if err != nil {
+				return managed.ExternalUpdate{}, err
+			}
+			c.recorder.Event(cr, event.Normal(reasonStopped, fmt.Sprintf(msgStopped, *previous.ExecutionID)))
+		default:
+			return managed.ExternalUpdate{}, errors.Errorf(errStillRunning, *previous.ExecutionID)
+		}
+	}
+
+	if cr.Spec.ForProvider.TaskDefinitionName == nil {
+		return managed.ExternalUpdate{}, errors.New(errNoTaskDefinition)
+	}
+
+	properties, err := c.service.LaunchProperties(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	hash := taskexecution.LaunchHash(string(cr.GetUID()), &cr.Spec.ForProvider, properties)
+
+	err = c.service.CheckCapacity(ctx, cr.Spec.ForProvider.Platform)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// Only the status is persisted after an update, therefore the external
+	// name is recorded explicitly
+	id, err := c.launchTask(ctx, cr, properties, hash)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	cr.Status.AtProvider = v1alpha1.TaskExecutionObservation{
+		ExecutionID: &id,
+		LaunchHash:  hash,
+		History:     taskexecution.AppendHistory(previous.History, &previous, cr.Spec.ForProvider.HistoryLimit),
+	}
+	cr.SetConditions(v1alpha1.Running())
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete stops the running execution
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.TaskExecution)
+	if !ok {
+		return errors.New(errNotTaskExecution)
+	}
+
+	id, err := executionId(cr)
+	if err != nil || id == nil {
+		return err
+	}
+
+	return c.service.Stop(ctx, *id, cr.Spec.ForProvider.Platform)
+}
+
+// executionId returns the id of the launched execution or nil, if the task
+// was not launched yet
+func executionId(cr *v1alpha1.TaskExecution) (*int64, error) {
+	name := meta.GetExternalName(cr)
+	if name == "" {
+		return nil, nil
+	}
+
+	id, err := strconv.ParseInt(name, 10, 64)
+	if err != nil {
+		return nil, errors.Errorf(errExecutionId, name)
+	}
+	return &id, nil
+}
@@ -0,0 +1,158 @@
+package taskexecutionretention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients/taskexecution"
+	"github.com/denniskniep/provider-springclouddataflow/internal/controllersdk"
+)
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+// A TaskExecutionRetention has no external resource, it exists once created
+// and is outdated, whenever the next cleanup is due.
+type external struct {
+	service  *taskexecution.TaskExecutionService
+	logger   logging.Logger
+	recorder event.Recorder
+}
+
+const (
+	errNotTaskExecutionRetention = "managed resource is not a TaskExecutionRetention custom resource"
+	errNoTaskDefinition          = "taskDefinitionName is not resolved"
+
+	diffCleanupDue = "cleanup is due"
+
+	reasonCleanedUp = "CleanedUp"
+	msgCleanedUp    = "Removed %d executions of task '%s'"
+	msgDryRun       = "Dry run, would remove %d executions of task '%s'"
+
+	defaultInterval = time.Hour
+	maxRecordedIds  = 100
+)
+
+func newExternalClient[R resource.Managed](conn *controllersdk.Connector[R], creds []byte) (managed.ExternalClient, error) {
+	service, err := taskexecution.NewTaskExecutionService(creds, conn.Kube)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{
+		service:  service,
+		logger:   conn.Logger,
+		recorder: conn.Recorder,
+	}, nil
+}
+
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	return controllersdk.Setup(v1alpha1.TaskExecutionRetentionGroupVersionKind, &v1alpha1.TaskExecutionRetention{}, mgr, o, newExternalClient[*v1alpha1.TaskExecutionRetention])
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.TaskExecutionRetention)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTaskExecutionRetention)
+	}
+
+	if meta.GetExternalName(cr) == "" || meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	last := cr.Status.AtProvider.LastCleanupTime
+	if last != nil && time.Since(last.Time) < interval(cr) {
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: diffCleanupDue}, nil
+}
+
+// Create only marks the retention as existing, the first cleanup is run by
+// the following update
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.TaskExecutionRetention)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTaskExecutionRetention)
+	}
+
+	if cr.Spec.ForProvider.TaskDefinitionName == nil {
+		return managed.ExternalCreation{}, errors.New(errNoTaskDefinition)
+	}
+
+	meta.SetExternalName(cr, *cr.Spec.ForProvider.TaskDefinitionName)
+	return managed.ExternalCreation{}, nil
+}
+
+// Update removes all completed executions, that are not retained anymore
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.TaskExecutionRetention)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTaskExecutionRetention)
+	}
+
+	spec := cr.Spec.ForProvider
+	if spec.TaskDefinitionName == nil {
+		return managed.ExternalUpdate{}, errors.New(errNoTaskDefinition)
+	}
+
+	executions, err := c.service.Executions(ctx, *spec.TaskDefinitionName)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	candidates := taskexecution.RetentionCandidates(executions, spec.MaxAgeDays, spec.KeepLast, time.Now())
+
+	msg := msgDryRun
+	if !spec.DryRun {
+		err = c.service.Remove(ctx, candidates)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		msg = msgCleanedUp
+		cr.Status.AtProvider.TotalRemovedCount += int64(len(candidates))
+	}
+
+	now := metav1.Now()
+	cr.Status.AtProvider.LastCleanupTime = &now
+	cr.Status.AtProvider.LastRemovedCount = len(candidates)
+	cr.Status.AtProvider.LastRemovedExecutionIDs = candidates
+	if len(candidates) > maxRecordedIds {
+		cr.Status.AtProvider.LastRemovedExecutionIDs = candidates[:maxRecordedIds]
+	}
+
+	if len(candidates) > 0 {
+		c.recorder.Event(cr, event.Normal(reasonCleanedUp, fmt.Sprintf(msg, len(candidates), *spec.TaskDefinitionName)))
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete is a no-op, removed executions can not be restored
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	return nil
+}
+
+func interval(cr *v1alpha1.TaskExecutionRetention) time.Duration {
+	if cr.Spec.ForProvider.Interval == nil || cr.Spec.ForProvider.Interval.Duration <= 0 {
+		return defaultInterval
+	}
+	return cr.Spec.ForProvider.Interval.Duration
+}
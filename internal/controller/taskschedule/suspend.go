@@ -0,0 +1,81 @@
+package taskschedule
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+)
+
+const (
+	errDescribeSuspended = "cannot describe suspended TaskSchedule"
+	errSuspend           = "cannot suspend TaskSchedule"
+
+	diffSuspended = "schedule is suspended"
+
+	reasonSuspended = "Suspended"
+	reasonResumed   = "Resumed"
+
+	msgSuspended = "Suspended '%s', the schedule was removed from the server"
+	msgResumed   = "Resumed '%s', the schedule was created again"
+)
+
+// observeSuspended reports the schedule as existing and up to date, once it
+// was removed from the server. The resource keeps its desired state, so that
+// it is created again, when it is resumed.
+func (c *external) observeSuspended(ctx context.Context, cr *v1alpha1.TaskSchedule) (managed.ExternalObservation, error) {
+	observed, err := c.service.Describe(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errDescribeSuspended)
+	}
+
+	if observed != nil {
+		// The schedule is removed by the update
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: diffSuspended}, nil
+	}
+
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	// The schedule did not exist, when the resource was suspended
+	status := &cr.Status.AtProvider
+	if !status.Suspended {
+		now := metav1.Now()
+		status.Suspended = true
+		status.SuspendedTime = &now
+	}
+
+	status.NextScheduledTimes = nil
+	cr.SetConditions(v1alpha1.Suspended().WithMessage(diffSuspended))
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+// suspend removes the schedule from the server and forgets what was applied,
+// so that it is recorded again, when the resource is resumed
+func (c *external) suspend(ctx context.Context, cr *v1alpha1.TaskSchedule) error {
+	err := c.service.Delete(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return errors.Wrap(err, errSuspend)
+	}
+
+	now := metav1.Now()
+	cr.Status.AtProvider = v1alpha1.TaskScheduleObservation{
+		ScheduleName:       cr.Status.AtProvider.ScheduleName,
+		TaskDefinitionName: cr.Status.AtProvider.TaskDefinitionName,
+		Suspended:          true,
+		SuspendedTime:      &now,
+	}
+	cr.SetConditions(v1alpha1.Suspended().WithMessage(diffSuspended))
+
+	c.recorder.Event(cr, event.Normal(reasonSuspended, fmt.Sprintf(msgSuspended, cr.Spec.ForProvider.ScheduleName)))
+	return nil
+}
@@ -2,14 +2,20 @@ package taskschedule
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 
 	"github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
 	"github.com/denniskniep/provider-springclouddataflow/internal/clients"
@@ -17,41 +23,193 @@ import (
 	"github.com/denniskniep/provider-springclouddataflow/internal/controllersdk"
 )
 
+type genericService = clients.Service[*v1alpha1.TaskSchedule, v1alpha1.TaskScheduleParameters, v1alpha1.TaskScheduleObservation, taskschedule.TaskScheduleCompare]
+
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	service clients.Service[*v1alpha1.TaskSchedule, v1alpha1.TaskScheduleParameters, v1alpha1.TaskScheduleObservation, taskschedule.TaskScheduleCompare]
-	logger  logging.Logger
+	service  *taskschedule.TaskScheduleService
+	logger   logging.Logger
+	recorder event.Recorder
 }
 
+const (
+	errNotTaskSchedule = "managed resource is not a TaskSchedule custom resource"
+	errUnschedule      = "cannot unschedule task"
+	errReschedule      = "cannot reschedule task"
+
+	diffProperties = "properties changed"
+
+	// hashProperties identifies the hash of the resolved properties, see
+	// controllersdk.HashUpToDate
+	hashProperties = "properties"
+
+	reasonUnscheduled      = "Unscheduled"
+	reasonUnscheduleFailed = "UnscheduleFailed"
+	reasonRescheduled      = "Rescheduled"
+	reasonRescheduleFailed = "RescheduleFailed"
+	reasonRestored         = "Restored"
+	reasonRestoreFailed    = "RestoreFailed"
+
+	msgUnscheduled = "Unscheduled '%s' to apply the changes"
+	msgRescheduled = "Rescheduled '%s' with cron expression '%s'"
+	msgRestored    = "Restored previous schedule '%s' with cron expression '%s'"
+	msgDegraded    = "Schedule was removed, rescheduling failed: %s, restoring failed: %s"
+)
+
 func newExternalClient[R resource.Managed](conn *controllersdk.Connector[R], creds []byte) (managed.ExternalClient, error) {
-	service, err := taskschedule.NewTaskScheduleService(creds)
+	service, err := taskschedule.NewTaskScheduleService(creds, conn.Kube)
 	if err != nil {
 		return nil, err
 	}
 
 	return &external{
-		service: service,
-		logger:  conn.Logger,
+		service:  service,
+		logger:   conn.Logger,
+		recorder: conn.Recorder,
 	}, nil
 }
 
 func Setup(mgr ctrl.Manager, o controller.Options) error {
-	return controllersdk.Setup(v1alpha1.TaskScheduleGroupVersionKind, &v1alpha1.TaskSchedule{}, mgr, o, newExternalClient[*v1alpha1.TaskSchedule])
+	watches := controllersdk.PropertySourceWatches(mgr.GetClient(),
+		func() client.ObjectList { return &v1alpha1.TaskScheduleList{} },
+		func(cr *v1alpha1.TaskSchedule) []v1alpha1.Property { return cr.Spec.ForProvider.PropertiesFrom })
+
+	return controllersdk.Setup(v1alpha1.TaskScheduleGroupVersionKind, &v1alpha1.TaskSchedule{}, mgr, o, newExternalClient[*v1alpha1.TaskSchedule], watches...)
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	return controllersdk.Observe(ctx, c.logger, c.service, mg)
+	cr, ok := mg.(*v1alpha1.TaskSchedule)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTaskSchedule)
+	}
+
+	if cr.Spec.ForProvider.Suspend {
+		return c.observeSuspended(ctx, cr)
+	}
+
+	// Schedulers, that do not report the arguments, properties and time
+	// zone, keep those of the spec at create
+	if cr.Status.AtProvider.Arguments == nil && meta.GetExternalName(cr) != "" {
+		cr.Status.AtProvider.Arguments = taskschedule.AppliedArguments(&cr.Spec.ForProvider)
+		cr.Status.AtProvider.Properties = taskschedule.SpecProperties(&cr.Spec.ForProvider)
+		cr.Status.AtProvider.TimeZone = cr.Spec.ForProvider.TimeZone
+	}
+
+	observation, err := controllersdk.Observe(ctx, c.logger, genericService(c.service), mg)
+	if err != nil || meta.WasDeleted(mg) {
+		return observation, err
+	}
+
+	err = c.validate(ctx, cr)
+	if err != nil || !observation.ResourceExists || !observation.ResourceUpToDate {
+		return observation, err
+	}
+
+	properties, err := c.service.ResolvedProperties(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return observation, err
+	}
+
+	hash := clients.HashProperties(string(cr.GetUID()), properties)
+	if !controllersdk.HashUpToDate(cr, hashProperties, &cr.Status.AtProvider.PropertiesHash, hash) {
+		observation.ResourceUpToDate = false
+		observation.Diff = diffProperties
+	}
+
+	return observation, nil
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	return controllersdk.Create(ctx, c.logger, c.service, mg)
+	cr, ok := mg.(*v1alpha1.TaskSchedule)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTaskSchedule)
+	}
+
+	properties, err := c.service.ResolvedProperties(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	err = c.service.CreateWithProperties(ctx, &cr.Spec.ForProvider, properties)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	meta.SetExternalName(cr, cr.Spec.ForProvider.ScheduleName)
+	controllersdk.RecordCreatedHash(cr, hashProperties, clients.HashProperties(string(cr.GetUID()), properties))
+	if cr.Status.AtProvider.Suspended {
+		c.recorder.Event(cr, event.Normal(reasonResumed, fmt.Sprintf(msgResumed, cr.Spec.ForProvider.ScheduleName)))
+	}
+	return managed.ExternalCreation{}, nil
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	return controllersdk.Update(ctx, c.logger, c.service, mg)
+	cr, ok := mg.(*v1alpha1.TaskSchedule)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTaskSchedule)
+	}
+
+	if cr.Spec.ForProvider.Suspend {
+		return managed.ExternalUpdate{}, c.suspend(ctx, cr)
+	}
+
+	properties, err := c.service.ResolvedProperties(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	err = c.reschedule(ctx, cr, properties)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	recordApplied(cr, properties)
+	return managed.ExternalUpdate{}, nil
+}
+
+// reschedule unschedules the task and schedules it again, as schedules can
+// not be updated by the server. If scheduling fails, the previous schedule
+// is restored. If restoring fails too, the resource is marked as degraded
+// until the schedule is created again.
+func (c *external) reschedule(ctx context.Context, cr *v1alpha1.TaskSchedule, properties map[string]string) error {
+	spec := &cr.Spec.ForProvider
+	previous := taskschedule.PreviousSchedule(spec, &cr.Status.AtProvider)
+
+	err := c.service.Delete(ctx, spec)
+	if err != nil {
+		c.recorder.Event(cr, event.Warning(reasonUnscheduleFailed, err))
+		return errors.Wrap(err, errUnschedule)
+	}
+	c.recorder.Event(cr, event.Normal(reasonUnscheduled, fmt.Sprintf(msgUnscheduled, spec.ScheduleName)))
+
+	err = c.service.CreateWithProperties(ctx, spec, properties)
+	if err == nil {
+		c.recorder.Event(cr, event.Normal(reasonRescheduled, fmt.Sprintf(msgRescheduled, spec.ScheduleName, spec.CronExpression)))
+		return nil
+	}
+	c.recorder.Event(cr, event.Warning(reasonRescheduleFailed, err))
+
+	restoreErr := c.service.CreateWithProperties(ctx, previous, properties)
+	if restoreErr != nil {
+		c.recorder.Event(cr, event.Warning(reasonRestoreFailed, restoreErr))
+		cr.SetConditions(v1alpha1.Degraded().WithMessage(fmt.Sprintf(msgDegraded, err.Error(), restoreErr.Error())))
+		return errors.Wrap(err, errReschedule)
+	}
+
+	c.recorder.Event(cr, event.Normal(reasonRestored, fmt.Sprintf(msgRestored, spec.ScheduleName, previous.CronExpression)))
+	return errors.Wrap(err, errReschedule)
+}
+
+// recordApplied records the applied properties, arguments and time zone,
+// that can not be observed
+func recordApplied(cr *v1alpha1.TaskSchedule, properties map[string]string) {
+	cr.Status.AtProvider.Arguments = taskschedule.AppliedArguments(&cr.Spec.ForProvider)
+	cr.Status.AtProvider.TimeZone = cr.Spec.ForProvider.TimeZone
+	cr.Status.AtProvider.Properties = taskschedule.SpecProperties(&cr.Spec.ForProvider)
+	cr.Status.AtProvider.PropertiesHash = clients.HashProperties(string(cr.GetUID()), properties)
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
-	return controllersdk.Delete(ctx, c.logger, c.service, mg)
+	return controllersdk.Delete(ctx, c.logger, genericService(c.service), mg)
 }
@@ -0,0 +1,50 @@
+package taskschedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients/taskschedule"
+)
+
+// Number of fire times previewed in the status
+const nextScheduledTimesCount = 5
+
+// validate checks, that the platform supports schedules and that the cron
+// expression and the time zone are valid for its type, and previews the next
+// fire times
+func (c *external) validate(ctx context.Context, cr *v1alpha1.TaskSchedule) error {
+	spec := &cr.Spec.ForProvider
+	platform, err := c.service.Platform(ctx, spec.Platform)
+
+	var platformErr *clients.PlatformError
+	if errors.As(err, &platformErr) {
+		cr.SetConditions(v1alpha1.InvalidPlatform().WithMessage(platformErr.Error()))
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	schedule, err := taskschedule.ParseCronExpression(spec.CronExpression, platform.Type)
+	if err != nil {
+		cr.SetConditions(v1alpha1.InvalidCronExpression().WithMessage(err.Error()))
+		cr.Status.AtProvider.NextScheduledTimes = nil
+		return err
+	}
+
+	location, err := taskschedule.ParseTimeZone(spec.TimeZone, platform.Type)
+	if err != nil {
+		cr.SetConditions(v1alpha1.InvalidTimeZone().WithMessage(err.Error()))
+		cr.Status.AtProvider.NextScheduledTimes = nil
+		return err
+	}
+
+	cr.SetConditions(v1alpha1.Valid())
+	cr.Status.AtProvider.NextScheduledTimes = taskschedule.NextScheduledTimes(schedule, time.Now().In(location), nextScheduledTimesCount)
+	return nil
+}
@@ -26,7 +26,10 @@ import (
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
 const (
@@ -53,11 +56,21 @@ type Connector[R resource.Managed] struct {
 	Kube                client.Client
 	Usage               resource.Tracker
 	Logger              logging.Logger
+	Recorder            event.Recorder
 	NewExternalClientFn func(conn *Connector[R], creds []byte) (managed.ExternalClient, error)
 }
 
+// A Watch reconciles the managed resources returned by Map, whenever an
+// object of the kind of Object changes (i.e. a referenced Secret) and all
+// Predicates match
+type Watch struct {
+	Object     client.Object
+	Map        handler.MapFunc
+	Predicates []predicate.Predicate
+}
+
 // Setup adds a controller that reconciles managed resources.
-func Setup[R resource.Managed](groupVersionKind schema.GroupVersionKind, newInstance R, mgr ctrl.Manager, o controller.Options, newExternalClientFn func(conn *Connector[R], creds []byte) (managed.ExternalClient, error)) error {
+func Setup[R resource.Managed](groupVersionKind schema.GroupVersionKind, newInstance R, mgr ctrl.Manager, o controller.Options, newExternalClientFn func(conn *Connector[R], creds []byte) (managed.ExternalClient, error), watches ...Watch) error {
 	name := managed.ControllerName(groupVersionKind.GroupKind().String())
 	o.Logger.Info("Setup Controller: " + name)
 
@@ -66,26 +79,33 @@ func Setup[R resource.Managed](groupVersionKind schema.GroupVersionKind, newInst
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
 	}
 
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(groupVersionKind),
 		managed.WithExternalConnecter(&Connector[R]{
 			Kube:                mgr.GetClient(),
 			Usage:               resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
 			NewExternalClientFn: newExternalClientFn,
+			Recorder:            recorder,
 			Logger:              o.Logger.WithValues("controller", name)}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithRecorder(recorder),
 		managed.WithInitializers(),
 		managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 		managed.WithConnectionPublishers(cps...))
 
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
-		WithEventFilter(resource.DesiredStateChanged()).
-		For(newInstance).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		For(newInstance, builder.WithPredicates(resource.DesiredStateChanged()))
+
+	for _, w := range watches {
+		b = b.Watches(w.Object, handler.EnqueueRequestsFromMapFunc(w.Map), builder.WithPredicates(w.Predicates...))
+	}
+
+	return b.Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
 }
 
 func (c *Connector[R]) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -137,6 +157,20 @@ func MapToCompare[C any](obj interface{}) (*C, error) {
 	return &objCompare, nil
 }
 
+func mapSpecToCompare[P any, O any, C any](srv any, spec *P) (*C, error) {
+	if mapper, ok := srv.(clients.CompareMapper[P, O, C]); ok {
+		return mapper.MapSpecToCompare(spec)
+	}
+	return MapToCompare[C](spec)
+}
+
+func mapObservationToCompare[P any, O any, C any](srv any, observed *O) (*C, error) {
+	if mapper, ok := srv.(clients.CompareMapper[P, O, C]); ok {
+		return mapper.MapObservationToCompare(observed)
+	}
+	return MapToCompare[C](observed)
+}
+
 func Observe[R resource.Managed, P any, O any, C any](ctx context.Context, logger logging.Logger, srv clients.Service[R, P, O, C], mg resource.Managed) (managed.ExternalObservation, error) {
 	logger = logger.WithValues("method", "observe")
 	logger.Debug("Start observe")
@@ -174,12 +208,12 @@ func Observe[R resource.Managed, P any, O any, C any](ctx context.Context, logge
 	srv.SetStatus(crWithAssert, observed)
 	cr.SetConditions(xpv1.Available().WithMessage("Managed resource exists"))
 
-	observedCompareable, err := MapToCompare[C](observed)
+	observedCompareable, err := mapObservationToCompare[P, O, C](srv, observed)
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errMappingObserved)
 	}
 
-	specCompareable, err := MapToCompare[C](spec)
+	specCompareable, err := mapSpecToCompare[P, O, C](srv, spec)
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errMappingSpec)
 	}
@@ -0,0 +1,62 @@
+package controllersdk
+
+import (
+	"context"
+	"crypto/rand"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errGetHashKey    = "cannot get hash key secret"
+	errCreateHashKey = "cannot create hash key secret"
+	errEmptyHashKey  = "hash key secret '%s/%s' has no key '%s'"
+
+	// HashKeySecretKey is the key of the hash key within its Secret
+	HashKeySecretKey = "key"
+
+	hashKeySize = 32
+)
+
+// LoadHashKey returns the key of the property hashes in the status from the
+// Secret. A missing Secret is created with a random key. The key is kept in
+// a Secret, so that the recorded hashes stay valid across restarts of the
+// provider.
+func LoadHashKey(ctx context.Context, kube client.Client, namespace string, name string) ([]byte, error) {
+	secret := &corev1.Secret{}
+	err := kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret)
+	if err == nil {
+		key := secret.Data[HashKeySecretKey]
+		if len(key) == 0 {
+			return nil, errors.Errorf(errEmptyHashKey, namespace, name, HashKeySecretKey)
+		}
+		return key, nil
+	}
+
+	if !kerrors.IsNotFound(err) {
+		return nil, errors.Wrap(err, errGetHashKey)
+	}
+
+	key := make([]byte, hashKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, errors.Wrap(err, errCreateHashKey)
+	}
+
+	err = kube.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Data:       map[string][]byte{HashKeySecretKey: key},
+	})
+	if kerrors.IsAlreadyExists(err) {
+		// Created by another replica of the provider in the meantime
+		return LoadHashKey(ctx, kube, namespace, name)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateHashKey)
+	}
+	return key, nil
+}
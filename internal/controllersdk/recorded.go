@@ -0,0 +1,53 @@
+package controllersdk
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// Values, that the server does not report, i.e. properties resolved from
+// Secrets and ConfigMaps, are tracked by a hash of the applied values. The
+// controller resolves the values once per change and records the hash of
+// exactly these values in the status, whenever it applies them. Observe
+// reports the resource as not up to date, once the hash of the desired
+// values differs from the recorded one.
+//
+// The status is not persisted after create, therefore create records the
+// hash in an annotation with RecordCreatedHash, which HashUpToDate takes
+// over into the status.
+
+// RecordCreatedHash records the hash of the values applied by create. The
+// name identifies the values, i.e. "properties".
+func RecordCreatedHash(mg resource.Managed, name string, hash string) {
+	meta.AddAnnotations(mg, map[string]string{annotationCreatedHash(name): hash})
+}
+
+// HashUpToDate reports whether the hash of the desired values equals the
+// recorded hash. Without recorded hash, the hash recorded by create is taken
+// over. Resources created before the hash was recorded are assumed to be up
+// to date.
+func HashUpToDate(mg resource.Managed, name string, recorded *string, hash string) bool {
+	if *recorded == "" {
+		created, ok := mg.GetAnnotations()[annotationCreatedHash(name)]
+		if !ok {
+			created = hash
+		}
+		*recorded = created
+	}
+	return *recorded == hash
+}
+
+func annotationCreatedHash(name string) string {
+	return "springclouddataflow.crossplane.io/created-" + name + "-hash"
+}
+
+// Invalid reports an invalid spec in the condition and returns the error.
+// Invalid specs are reported by observe instead of create, because
+// conditions set during create are not persisted, while the error of observe
+// persists them.
+func Invalid(mg resource.Managed, condition xpv1.Condition, err error) (managed.ExternalObservation, error) {
+	mg.SetConditions(condition)
+	return managed.ExternalObservation{}, err
+}
@@ -0,0 +1,67 @@
+package controllersdk
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	core "github.com/denniskniep/provider-springclouddataflow/apis/core/v1alpha1"
+	"github.com/denniskniep/provider-springclouddataflow/internal/clients"
+)
+
+// PropertySourceCache limits the cached Secrets and ConfigMaps to the ones
+// labeled with core.PropertySourceLabel, so that not every Secret of the
+// cluster is cached. Secrets and ConfigMaps are read from the API server
+// instead, as they are not necessarily labeled.
+func PropertySourceCache(o ctrl.Options) ctrl.Options {
+	selector := labels.SelectorFromSet(labels.Set{core.PropertySourceLabel: "true"})
+	o.Cache.ByObject = map[client.Object]cache.ByObject{
+		&corev1.Secret{}:    {Label: selector},
+		&corev1.ConfigMap{}: {Label: selector},
+	}
+	o.Client.Cache = &client.CacheOptions{
+		DisableFor: []client.Object{&corev1.Secret{}, &corev1.ConfigMap{}},
+	}
+	return o
+}
+
+// PropertySourceWatches reconciles all managed resources, whose properties
+// read their values from a Secret or ConfigMap, when it changes. Only Secrets
+// and ConfigMaps labeled with core.PropertySourceLabel are watched, see
+// PropertySourceCache.
+func PropertySourceWatches[R resource.Managed](kube client.Reader, newList func() client.ObjectList, properties func(R) []core.Property) []Watch {
+	mapFn := func(ctx context.Context, obj client.Object) []reconcile.Request {
+		list := newList()
+		if err := kube.List(ctx, list); err != nil {
+			// The resources are reconciled with the next poll anyway
+			return nil
+		}
+
+		items, err := apimeta.ExtractList(list)
+		if err != nil {
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, item := range items {
+			mg, ok := item.(R)
+			if ok && clients.ReferencesObject(properties(mg), obj) {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: mg.GetName()}})
+			}
+		}
+		return requests
+	}
+
+	return []Watch{
+		{Object: &corev1.Secret{}, Map: mapFn},
+		{Object: &corev1.ConfigMap{}, Map: mapFn},
+	}
+}
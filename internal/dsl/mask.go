@@ -0,0 +1,102 @@
+package dsl
+
+import "strings"
+
+// MaskedValue replaces the values of sensitive arguments in the DSL returned
+// by the server
+const MaskedValue = "******"
+
+// Argument names are sensitive, if they end with one of these keys. Names
+// containing "credentials" are always sensitive.
+var sensitiveKeys = []string{"username", "password", "secret", "key", "token", "vcap_services", "url"}
+
+// Sensitive reports whether the server masks the value of the argument
+func Sensitive(name string) bool {
+	name = strings.ToLower(name)
+	if strings.Contains(name, "credentials") {
+		return true
+	}
+
+	for _, key := range sensitiveKeys {
+		if strings.HasSuffix(name, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func maskArguments(arguments []*ArgumentNode) {
+	for _, argument := range arguments {
+		if Sensitive(argument.Name) {
+			argument.Value = MaskedValue
+		}
+	}
+}
+
+// Mask replaces the values of all sensitive arguments as the server does, so
+// that a definition can be compared with the one returned by the server
+func (n *StreamNode) Mask() {
+	for _, app := range n.Apps {
+		maskArguments(app.Arguments)
+	}
+}
+
+// Mask replaces the values of all sensitive arguments of the task apps, like
+// the Mask of streams
+func (n *TaskNode) Mask() {
+	n.Flow.walk(func(app *TaskAppNode) {
+		maskArguments(app.Arguments)
+	})
+}
+
+// SensitiveArguments returns the values of all sensitive arguments keyed by
+// "<app label or name>.<argument name>". The server masks these values,
+// therefore changes of them can only be detected by comparing with the
+// applied values.
+func (n *StreamNode) SensitiveArguments() map[string]string {
+	arguments := map[string]string{}
+	for _, app := range n.Apps {
+		addSensitiveArguments(arguments, app.LabelOrName(), app.Arguments)
+	}
+	return arguments
+}
+
+// SensitiveArguments returns the values of all sensitive arguments of the
+// task apps, like the SensitiveArguments of streams
+func (n *TaskNode) SensitiveArguments() map[string]string {
+	arguments := map[string]string{}
+	for _, app := range n.Apps() {
+		addSensitiveArguments(arguments, app.LabelOrName(), app.Arguments)
+	}
+	return arguments
+}
+
+func addSensitiveArguments(sensitive map[string]string, app string, arguments []*ArgumentNode) {
+	for _, argument := range arguments {
+		if Sensitive(argument.Name) {
+			sensitive[app+"."+argument.Name] = argument.Value
+		}
+	}
+}
+
+// Unmask replaces the masked values of sensitive task app arguments with the
+// given values, keyed like SensitiveArguments. It reports false, if a masked
+// value is not among them.
+func (n *TaskNode) Unmask(values map[string]string) bool {
+	complete := true
+	for _, app := range n.Apps() {
+		for _, argument := range app.Arguments {
+			if argument.Value != MaskedValue || !Sensitive(argument.Name) {
+				continue
+			}
+
+			value, ok := values[app.LabelOrName()+"."+argument.Name]
+			if !ok {
+				complete = false
+				continue
+			}
+			argument.Value = value
+		}
+	}
+	return complete
+}
@@ -0,0 +1,131 @@
+package dsl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMaskStream(t *testing.T) {
+	cases := map[string]struct {
+		definition string
+		want       string
+	}{
+		"NoSensitiveArguments": {
+			definition: "time --format=yyyy | log",
+			want:       "time --format=yyyy | log",
+		},
+		"SensitiveArguments": {
+			definition: "jdbc --spring.datasource.password=pw --spring.datasource.url=jdbc:h2 --table=t | log --my.api-token=x",
+			want:       "jdbc --spring.datasource.password=****** --spring.datasource.url=****** --table=t | log --my.api-token=******",
+		},
+		"CaseInsensitive": {
+			definition: "http --PASSWORD=pw --aws.Credentials.id=id | log",
+			want:       "http --PASSWORD=****** --aws.Credentials.id=****** | log",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			stream, err := ParseStream(tc.definition)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stream.Mask()
+			if got := stream.String(); got != tc.want {
+				t.Errorf("Mask(): expected '%s', got '%s'", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMaskTask(t *testing.T) {
+	cases := map[string]struct {
+		definition string
+		want       string
+	}{
+		"SingleApp": {
+			definition: "timestamp --format=yyyy --db.password=pw",
+			want:       "timestamp --format=yyyy --db.password=******",
+		},
+		"ComposedWithTransitions": {
+			definition: "a: timestamp --api-key=k 'FAILED'->b: cleanup --token=t && <c: timestamp --user.credentials=c || d: timestamp>",
+			want:       "a: timestamp --api-key=****** 'FAILED'->b: cleanup --token=****** && <c: timestamp --user.credentials=****** || d: timestamp>",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			task, err := ParseTask(tc.definition)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			task.Mask()
+			if got := task.String(); got != tc.want {
+				t.Errorf("Mask(): expected '%s', got '%s'", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSensitiveArguments(t *testing.T) {
+	stream, err := ParseStream("jdbc --password=pw --table=t | out: rabbit --routing-key=a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{"jdbc.password": "pw", "out.routing-key": "a"}
+	if diff := cmp.Diff(want, stream.SensitiveArguments()); diff != "" {
+		t.Errorf("SensitiveArguments(): -want, +got:\n%s", diff)
+	}
+
+	task, err := ParseTask("a: timestamp --api-key=k 'FAILED'->b: cleanup --token=t && c: timestamp --format=yyyy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want = map[string]string{"a.api-key": "k", "b.token": "t"}
+	if diff := cmp.Diff(want, task.SensitiveArguments()); diff != "" {
+		t.Errorf("SensitiveArguments(): -want, +got:\n%s", diff)
+	}
+}
+
+func TestUnmaskTask(t *testing.T) {
+	cases := map[string]struct {
+		definition   string
+		values       map[string]string
+		want         string
+		wantComplete bool
+	}{
+		"AllValues": {
+			definition:   "a: timestamp --api-key=****** && b: cleanup --token=****** --format=yyyy",
+			values:       map[string]string{"a.api-key": "k", "b.token": "t"},
+			want:         "a: timestamp --api-key=k && b: cleanup --token=t --format=yyyy",
+			wantComplete: true,
+		},
+		"MissingValue": {
+			definition:   "a: timestamp --api-key=****** && b: cleanup --token=******",
+			values:       map[string]string{"a.api-key": "k"},
+			want:         "a: timestamp --api-key=k && b: cleanup --token=******",
+			wantComplete: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			task, err := ParseTask(tc.definition)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if complete := task.Unmask(tc.values); complete != tc.wantComplete {
+				t.Errorf("Unmask(): expected %t, got %t", tc.wantComplete, complete)
+			}
+			if got := task.String(); got != tc.want {
+				t.Errorf("Unmask(): expected '%s', got '%s'", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+// Package dsl parses and renders the Spring Cloud Data Flow stream and
+// composed-task DSL.
+package dsl
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ParseError is returned if a definition is not valid DSL
+type ParseError struct {
+	Input    string
+	Position int
+	Message  string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s at position %d in '%s'", e.Message, e.Position, e.Input)
+}
+
+// Runes terminating an unquoted argument value. Like the Data Flow tokenizer
+// a '>' does not end a value, i.e. "--expression=payload>5", named
+// destinations are only recognized after whitespace.
+const argValueTerminators = " \t\r\n|;"
+
+type scanner struct {
+	input []rune
+	pos   int
+}
+
+func newScanner(input string) *scanner {
+	return &scanner{input: []rune(input)}
+}
+
+func (s *scanner) errorf(format string, args ...any) error {
+	return &ParseError{
+		Input:    string(s.input),
+		Position: s.pos,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+func (s *scanner) skipWhitespace() {
+	for s.pos < len(s.input) && unicode.IsSpace(s.input[s.pos]) {
+		s.pos++
+	}
+}
+
+func (s *scanner) eof() bool {
+	s.skipWhitespace()
+	return s.pos >= len(s.input)
+}
+
+// peek reports whether the next token is the given one, without consuming it
+func (s *scanner) peek(token string) bool {
+	s.skipWhitespace()
+	return strings.HasPrefix(string(s.input[s.pos:]), token)
+}
+
+// accept consumes the given token, if it is the next one
+func (s *scanner) accept(token string) bool {
+	if !s.peek(token) {
+		return false
+	}
+	s.pos += len([]rune(token))
+	return true
+}
+
+func (s *scanner) expect(token string) error {
+	if !s.accept(token) {
+		return s.errorf("expected '%s'", token)
+	}
+	return nil
+}
+
+// peekIdentifierFollowedBy reports whether the next tokens are an identifier
+// and the given token, without consuming them
+func (s *scanner) peekIdentifierFollowedBy(token string) bool {
+	start := s.pos
+	defer func() { s.pos = start }()
+
+	if _, err := s.identifier(); err != nil {
+		return false
+	}
+	return s.peek(token)
+}
+
+func isIdentifierRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-.$", r)
+}
+
+func (s *scanner) identifier() (string, error) {
+	s.skipWhitespace()
+	start := s.pos
+	for s.pos < len(s.input) && isIdentifierRune(s.input[s.pos]) {
+		// "->" starts a transition and is never part of an identifier
+		if s.input[s.pos] == '-' && s.pos+1 < len(s.input) && s.input[s.pos+1] == '>' {
+			break
+		}
+		s.pos++
+	}
+
+	if start == s.pos {
+		return "", s.errorf("expected identifier")
+	}
+	return string(s.input[start:s.pos]), nil
+}
+
+// quoted reads a string enclosed in single or double quotes. Within the
+// string the enclosing quote is escaped by doubling it.
+func (s *scanner) quoted() (string, error) {
+	s.skipWhitespace()
+	if s.pos >= len(s.input) || (s.input[s.pos] != '\'' && s.input[s.pos] != '"') {
+		return "", s.errorf("expected quoted string")
+	}
+
+	quote := s.input[s.pos]
+	start := s.pos
+	s.pos++
+
+	var value strings.Builder
+	for s.pos < len(s.input) {
+		r := s.input[s.pos]
+		s.pos++
+		if r != quote {
+			value.WriteRune(r)
+			continue
+		}
+		if s.pos < len(s.input) && s.input[s.pos] == quote {
+			value.WriteRune(quote)
+			s.pos++
+			continue
+		}
+		return value.String(), nil
+	}
+
+	s.pos = start
+	return "", s.errorf("unterminated quoted string")
+}
+
+// argValue reads the value of an argument. A value starting with a quote
+// is read as quoted string, otherwise the value ends at the first unquoted
+// terminator.
+func (s *scanner) argValue() (string, error) {
+	if s.pos < len(s.input) && (s.input[s.pos] == '\'' || s.input[s.pos] == '"') {
+		return s.quoted()
+	}
+
+	start := s.pos
+	var openQuote rune
+	for s.pos < len(s.input) {
+		r := s.input[s.pos]
+		if openQuote == 0 && strings.ContainsRune(argValueTerminators, r) {
+			break
+		}
+		switch {
+		case openQuote == 0 && (r == '\'' || r == '"'):
+			openQuote = r
+		case r == openQuote:
+			openQuote = 0
+		}
+		s.pos++
+	}
+
+	if openQuote != 0 {
+		return "", s.errorf("unterminated quoted string in argument value")
+	}
+	return string(s.input[start:s.pos]), nil
+}
+
+// arguments reads all following "--<name>=<value>" arguments
+func (s *scanner) arguments() ([]*ArgumentNode, error) {
+	var arguments []*ArgumentNode
+	for s.accept("--") {
+		name, err := s.identifier()
+		if err != nil {
+			return nil, err
+		}
+
+		// No whitespace is allowed around the equals sign
+		if s.pos >= len(s.input) || s.input[s.pos] != '=' {
+			return nil, s.errorf("expected '=' after argument '%s'", name)
+		}
+		s.pos++
+
+		value, err := s.argValue()
+		if err != nil {
+			return nil, err
+		}
+
+		arguments = append(arguments, &ArgumentNode{Name: name, Value: value})
+	}
+	return arguments, nil
+}
+
+// QuoteArgValue returns the value as it has to be written in the DSL. The
+// value is wrapped in single quotes, if it would not be read as one argument
+// value otherwise. Single quotes within are escaped by doubling them.
+func QuoteArgValue(value string) string {
+	// Values with '>' are quoted as well, so that they are never mistaken for
+	// a named destination
+	if value != "" && !strings.ContainsAny(value, argValueTerminators+">'\"") {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// ArgumentNode is a "--<name>=<value>" argument of an app
+type ArgumentNode struct {
+	Name  string
+	Value string
+}
+
+func (a *ArgumentNode) String() string {
+	return "--" + a.Name + "=" + QuoteArgValue(a.Value)
+}
+
+func renderArguments(builder *strings.Builder, arguments []*ArgumentNode) {
+	for _, argument := range arguments {
+		builder.WriteString(" " + argument.String())
+	}
+}
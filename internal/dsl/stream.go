@@ -0,0 +1,289 @@
+package dsl
+
+import (
+	"sort"
+	"strings"
+)
+
+// StreamNode is a parsed stream definition, i.e.
+// "mystream = :in > transform --expression=payload | log > :out"
+type StreamNode struct {
+	// Name of the stream, only set if the definition starts with "<name> ="
+	Name string
+
+	// Named destination the stream consumes from
+	Source *DestinationNode
+
+	// Apps of the stream in the order they are piped together
+	Apps []*AppNode
+
+	// Unbound is true, if the apps are separated by "||" instead of "|"
+	Unbound bool
+
+	// Named destination the stream produces to
+	Sink *DestinationNode
+}
+
+// DestinationNode is a named destination, i.e. ":mytopic" or the tap
+// ":mainstream.transform"
+type DestinationNode struct {
+	Name string
+}
+
+// Tap splits the destination into stream and app, if it has the form of a
+// tap (<stream>.<app>). Whether the stream exists can only be decided by the
+// caller, as named destinations may contain dots as well.
+func (d *DestinationNode) Tap() (stream string, app string, ok bool) {
+	stream, app, ok = strings.Cut(d.Name, ".")
+	if !ok || stream == "" || app == "" {
+		return "", "", false
+	}
+	return stream, app, true
+}
+
+func (d *DestinationNode) String() string {
+	return ":" + d.Name
+}
+
+// AppNode is a registered app used in a stream, i.e.
+// "upper: transform --expression=payload.toUpperCase()"
+type AppNode struct {
+	Label     string
+	Name      string
+	Arguments []*ArgumentNode
+}
+
+// LabelOrName returns the name, the app is referenced with in the stream
+func (a *AppNode) LabelOrName() string {
+	if a.Label != "" {
+		return a.Label
+	}
+	return a.Name
+}
+
+func (a *AppNode) String() string {
+	var builder strings.Builder
+	if a.Label != "" {
+		builder.WriteString(a.Label + ": ")
+	}
+	builder.WriteString(a.Name)
+	renderArguments(&builder, a.Arguments)
+	return builder.String()
+}
+
+// ParseStream parses a stream definition
+func ParseStream(definition string) (*StreamNode, error) {
+	s := newScanner(definition)
+	stream := &StreamNode{}
+
+	if s.peekIdentifierFollowedBy("=") {
+		name, _ := s.identifier()
+		stream.Name = name
+		s.accept("=")
+	}
+
+	if s.peek(":") {
+		source, err := s.destination()
+		if err != nil {
+			return nil, err
+		}
+		stream.Source = source
+
+		if err := s.expect(">"); err != nil {
+			return nil, err
+		}
+	}
+
+	// A stream without apps bridges two named destinations
+	if stream.Source == nil || !s.peek(":") {
+		apps, unbound, err := s.streamApps()
+		if err != nil {
+			return nil, err
+		}
+		stream.Apps = apps
+		stream.Unbound = unbound
+	}
+
+	if stream.Apps == nil || s.accept(">") {
+		sink, err := s.destination()
+		if err != nil {
+			return nil, err
+		}
+		stream.Sink = sink
+	}
+
+	if !s.eof() {
+		return nil, s.errorf("unexpected '%s'", string(s.input[s.pos]))
+	}
+
+	return stream, nil
+}
+
+func (s *scanner) destination() (*DestinationNode, error) {
+	if err := s.expect(":"); err != nil {
+		return nil, err
+	}
+
+	name, err := s.identifier()
+	if err != nil {
+		return nil, err
+	}
+	return &DestinationNode{Name: name}, nil
+}
+
+func (s *scanner) streamApps() ([]*AppNode, bool, error) {
+	var apps []*AppNode
+	unbound := false
+
+	for {
+		app, err := s.streamApp()
+		if err != nil {
+			return nil, false, err
+		}
+		apps = append(apps, app)
+
+		switch {
+		case s.accept("||"):
+			if len(apps) > 1 && !unbound {
+				return nil, false, s.errorf("'|' and '||' can not be mixed")
+			}
+			unbound = true
+		case s.accept("|"):
+			if unbound {
+				return nil, false, s.errorf("'|' and '||' can not be mixed")
+			}
+		default:
+			return apps, unbound, nil
+		}
+	}
+}
+
+func (s *scanner) streamApp() (*AppNode, error) {
+	app := &AppNode{}
+
+	if s.peekIdentifierFollowedBy(":") {
+		label, _ := s.identifier()
+		app.Label = label
+		s.accept(":")
+	}
+
+	name, err := s.identifier()
+	if err != nil {
+		return nil, err
+	}
+	app.Name = name
+
+	arguments, err := s.arguments()
+	if err != nil {
+		return nil, err
+	}
+	app.Arguments = arguments
+
+	return app, nil
+}
+
+// String renders the stream to DSL. The name of the stream is not part of
+// the rendered definition.
+func (n *StreamNode) String() string {
+	segments := make([]string, 0, 3)
+
+	if n.Source != nil {
+		segments = append(segments, n.Source.String())
+	}
+
+	if len(n.Apps) > 0 {
+		apps := make([]string, 0, len(n.Apps))
+		for _, app := range n.Apps {
+			apps = append(apps, app.String())
+		}
+
+		separator := " | "
+		if n.Unbound {
+			separator = " || "
+		}
+		segments = append(segments, strings.Join(apps, separator))
+	}
+
+	if n.Sink != nil {
+		segments = append(segments, n.Sink.String())
+	}
+
+	return strings.Join(segments, " > ")
+}
+
+// AppNames returns the distinct names of the registered apps used by the
+// stream
+func (n *StreamNode) AppNames() []string {
+	var names []string
+	for _, app := range n.Apps {
+		names = appendDistinct(names, app.Name)
+	}
+	return names
+}
+
+// Destinations returns the names of all named destinations of the stream
+func (n *StreamNode) Destinations() []string {
+	var names []string
+	for _, destination := range []*DestinationNode{n.Source, n.Sink} {
+		if destination != nil {
+			names = appendDistinct(names, destination.Name)
+		}
+	}
+	return names
+}
+
+// TappedStream returns the stream name, if the source has the form of a tap
+func (n *StreamNode) TappedStream() (string, bool) {
+	if n.Source == nil {
+		return "", false
+	}
+	stream, _, ok := n.Source.Tap()
+	return stream, ok
+}
+
+// Canonical returns the DSL with all app arguments sorted by name, so that
+// equivalent definitions render equally
+func (n *StreamNode) Canonical() string {
+	canonical := *n
+	canonical.Apps = make([]*AppNode, 0, len(n.Apps))
+	for _, app := range n.Apps {
+		sortedApp := *app
+		sortedApp.Arguments = sortArguments(app.Arguments)
+		canonical.Apps = append(canonical.Apps, &sortedApp)
+	}
+	return canonical.String()
+}
+
+// EquivalentStreams reports whether both stream definitions describe the
+// same stream, regardless of whitespace, quoting and argument order
+func EquivalentStreams(a string, b string) (bool, error) {
+	streamA, err := ParseStream(a)
+	if err != nil {
+		return false, err
+	}
+
+	streamB, err := ParseStream(b)
+	if err != nil {
+		return false, err
+	}
+
+	return streamA.Canonical() == streamB.Canonical(), nil
+}
+
+func sortArguments(arguments []*ArgumentNode) []*ArgumentNode {
+	sorted := make([]*ArgumentNode, len(arguments))
+	copy(sorted, arguments)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+func appendDistinct(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
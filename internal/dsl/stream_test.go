@@ -0,0 +1,370 @@
+package dsl
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseStream(t *testing.T) {
+	cases := map[string]struct {
+		definition   string
+		want         *StreamNode
+		rendered     string
+		appNames     []string
+		destinations []string
+		tappedStream string
+	}{
+		"TimeLog": {
+			definition: "time | log",
+			want: &StreamNode{
+				Apps: []*AppNode{{Name: "time"}, {Name: "log"}},
+			},
+			rendered: "time | log",
+			appNames: []string{"time", "log"},
+		},
+		"NamedStream": {
+			definition: "ticktock = time | log",
+			want: &StreamNode{
+				Name: "ticktock",
+				Apps: []*AppNode{{Name: "time"}, {Name: "log"}},
+			},
+			rendered: "time | log",
+			appNames: []string{"time", "log"},
+		},
+		"Arguments": {
+			definition: "http --server.port=9000 --path-pattern=/in | log --log.level=WARN",
+			want: &StreamNode{
+				Apps: []*AppNode{
+					{Name: "http", Arguments: []*ArgumentNode{{Name: "server.port", Value: "9000"}, {Name: "path-pattern", Value: "/in"}}},
+					{Name: "log", Arguments: []*ArgumentNode{{Name: "log.level", Value: "WARN"}}},
+				},
+			},
+			rendered: "http --server.port=9000 --path-pattern=/in | log --log.level=WARN",
+			appNames: []string{"http", "log"},
+		},
+		"TransformExpression": {
+			definition: "http --port=8090 | transform --expression=payload.toUpperCase() | log",
+			want: &StreamNode{
+				Apps: []*AppNode{
+					{Name: "http", Arguments: []*ArgumentNode{{Name: "port", Value: "8090"}}},
+					{Name: "transform", Arguments: []*ArgumentNode{{Name: "expression", Value: "payload.toUpperCase()"}}},
+					{Name: "log"},
+				},
+			},
+			rendered: "http --port=8090 | transform --expression=payload.toUpperCase() | log",
+			appNames: []string{"http", "transform", "log"},
+		},
+		"GreaterThanInArgument": {
+			definition: "time | filter --expression=payload>5 > :big",
+			want: &StreamNode{
+				Apps: []*AppNode{
+					{Name: "time"},
+					{Name: "filter", Arguments: []*ArgumentNode{{Name: "expression", Value: "payload>5"}}},
+				},
+				Sink: &DestinationNode{Name: "big"},
+			},
+			rendered:     "time | filter --expression='payload>5' > :big",
+			appNames:     []string{"time", "filter"},
+			destinations: []string{"big"},
+		},
+		"SingleQuotedArgument": {
+			definition: "time | filter --expression='payload != null' | log",
+			want: &StreamNode{
+				Apps: []*AppNode{
+					{Name: "time"},
+					{Name: "filter", Arguments: []*ArgumentNode{{Name: "expression", Value: "payload != null"}}},
+					{Name: "log"},
+				},
+			},
+			rendered: "time | filter --expression='payload != null' | log",
+			appNames: []string{"time", "filter", "log"},
+		},
+		"DoubleQuotedArgumentWithSingleQuotes": {
+			definition: `http | filter --expression="payload.contains('hello')" | log`,
+			want: &StreamNode{
+				Apps: []*AppNode{
+					{Name: "http"},
+					{Name: "filter", Arguments: []*ArgumentNode{{Name: "expression", Value: "payload.contains('hello')"}}},
+					{Name: "log"},
+				},
+			},
+			rendered: "http | filter --expression='payload.contains(''hello'')' | log",
+			appNames: []string{"http", "filter", "log"},
+		},
+		"EscapedSingleQuotes": {
+			definition: "http | transform --expression='''hello '' + payload' | log",
+			want: &StreamNode{
+				Apps: []*AppNode{
+					{Name: "http"},
+					{Name: "transform", Arguments: []*ArgumentNode{{Name: "expression", Value: "'hello ' + payload"}}},
+					{Name: "log"},
+				},
+			},
+			rendered: "http | transform --expression='''hello '' + payload' | log",
+			appNames: []string{"http", "transform", "log"},
+		},
+		"QuotesWithinArgument": {
+			definition: "http | filter --expression=payload.matches('a b') | log",
+			want: &StreamNode{
+				Apps: []*AppNode{
+					{Name: "http"},
+					{Name: "filter", Arguments: []*ArgumentNode{{Name: "expression", Value: "payload.matches('a b')"}}},
+					{Name: "log"},
+				},
+			},
+			rendered: "http | filter --expression='payload.matches(''a b'')' | log",
+			appNames: []string{"http", "filter", "log"},
+		},
+		"ArgumentWithCommaAndEquals": {
+			definition: "jdbc --columns=a,b --query=select=1 | log",
+			want: &StreamNode{
+				Apps: []*AppNode{
+					{Name: "jdbc", Arguments: []*ArgumentNode{{Name: "columns", Value: "a,b"}, {Name: "query", Value: "select=1"}}},
+					{Name: "log"},
+				},
+			},
+			rendered: "jdbc --columns=a,b --query=select=1 | log",
+			appNames: []string{"jdbc", "log"},
+		},
+		"EmptyArgument": {
+			definition: "time | log --name=''",
+			want: &StreamNode{
+				Apps: []*AppNode{
+					{Name: "time"},
+					{Name: "log", Arguments: []*ArgumentNode{{Name: "name", Value: ""}}},
+				},
+			},
+			rendered: "time | log --name=''",
+			appNames: []string{"time", "log"},
+		},
+		"Labels": {
+			definition: "http | t1: transform --expression=payload | t2: transform --expression=payload | log",
+			want: &StreamNode{
+				Apps: []*AppNode{
+					{Name: "http"},
+					{Label: "t1", Name: "transform", Arguments: []*ArgumentNode{{Name: "expression", Value: "payload"}}},
+					{Label: "t2", Name: "transform", Arguments: []*ArgumentNode{{Name: "expression", Value: "payload"}}},
+					{Name: "log"},
+				},
+			},
+			rendered: "http | t1: transform --expression=payload | t2: transform --expression=payload | log",
+			appNames: []string{"http", "transform", "log"},
+		},
+		"Tap": {
+			definition: ":mainstream.transform > log",
+			want: &StreamNode{
+				Source: &DestinationNode{Name: "mainstream.transform"},
+				Apps:   []*AppNode{{Name: "log"}},
+			},
+			rendered:     ":mainstream.transform > log",
+			appNames:     []string{"log"},
+			destinations: []string{"mainstream.transform"},
+			tappedStream: "mainstream",
+		},
+		"SinkDestination": {
+			definition: "http > :data",
+			want: &StreamNode{
+				Apps: []*AppNode{{Name: "http"}},
+				Sink: &DestinationNode{Name: "data"},
+			},
+			rendered:     "http > :data",
+			appNames:     []string{"http"},
+			destinations: []string{"data"},
+		},
+		"SourceDestination": {
+			definition: ":data > log",
+			want: &StreamNode{
+				Source: &DestinationNode{Name: "data"},
+				Apps:   []*AppNode{{Name: "log"}},
+			},
+			rendered:     ":data > log",
+			appNames:     []string{"log"},
+			destinations: []string{"data"},
+		},
+		"SourceAndSinkDestination": {
+			definition: ":in > transform --expression=payload > :out",
+			want: &StreamNode{
+				Source: &DestinationNode{Name: "in"},
+				Apps:   []*AppNode{{Name: "transform", Arguments: []*ArgumentNode{{Name: "expression", Value: "payload"}}}},
+				Sink:   &DestinationNode{Name: "out"},
+			},
+			rendered:     ":in > transform --expression=payload > :out",
+			appNames:     []string{"transform"},
+			destinations: []string{"in", "out"},
+		},
+		"Bridge": {
+			definition: ":foo > :bar",
+			want: &StreamNode{
+				Source: &DestinationNode{Name: "foo"},
+				Sink:   &DestinationNode{Name: "bar"},
+			},
+			rendered:     ":foo > :bar",
+			destinations: []string{"foo", "bar"},
+		},
+		"UnboundApps": {
+			definition: "app1 || app2 --server.port=8081",
+			want: &StreamNode{
+				Apps: []*AppNode{
+					{Name: "app1"},
+					{Name: "app2", Arguments: []*ArgumentNode{{Name: "server.port", Value: "8081"}}},
+				},
+				Unbound: true,
+			},
+			rendered: "app1 || app2 --server.port=8081",
+			appNames: []string{"app1", "app2"},
+		},
+		"Whitespace": {
+			definition: "  time   |log\t|  log  ",
+			want: &StreamNode{
+				Apps: []*AppNode{{Name: "time"}, {Name: "log"}, {Name: "log"}},
+			},
+			rendered: "time | log | log",
+			appNames: []string{"time", "log"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseStream(tc.definition)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseStream(...): -want, +got:\n%s", diff)
+			}
+
+			if rendered := got.String(); rendered != tc.rendered {
+				t.Errorf("String(): expected '%s', got '%s'", tc.rendered, rendered)
+			}
+
+			// Rendering must round-trip to the same AST
+			reparsed, err := ParseStream(got.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			reparsed.Name = got.Name
+			if diff := cmp.Diff(got, reparsed); diff != "" {
+				t.Errorf("round-trip: -want, +got:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.appNames, got.AppNames()); diff != "" {
+				t.Errorf("AppNames(): -want, +got:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.destinations, got.Destinations()); diff != "" {
+				t.Errorf("Destinations(): -want, +got:\n%s", diff)
+			}
+
+			tappedStream, _ := got.TappedStream()
+			if tappedStream != tc.tappedStream {
+				t.Errorf("TappedStream(): expected '%s', got '%s'", tc.tappedStream, tappedStream)
+			}
+		})
+	}
+}
+
+func TestParseStreamErrors(t *testing.T) {
+	cases := map[string]string{
+		"Empty":                   "",
+		"TrailingPipe":            "time |",
+		"LeadingPipe":             "| log",
+		"MissingPipe":             "time log",
+		"MixedPipes":              "a | b || c",
+		"ArgumentWithoutValue":    "time --fixed-delay | log",
+		"ArgumentWithSpaces":      "time --fixed-delay = 5 | log",
+		"UnterminatedQuote":       "time | filter --expression='payload | log",
+		"SourceWithoutTarget":     ":in >",
+		"SinkWithoutDestination":  "time >",
+		"SinkWithoutColon":        "time > out",
+		"DestinationWithoutArrow": ":in log",
+	}
+
+	for name, definition := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseStream(definition)
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("expected ParseError for '%s', got %v", definition, err)
+			}
+		})
+	}
+}
+
+func TestEquivalentStreams(t *testing.T) {
+	cases := map[string]struct {
+		a    string
+		b    string
+		want bool
+	}{
+		"Equal": {
+			a:    "time | log",
+			b:    "time | log",
+			want: true,
+		},
+		"WhitespaceAndQuoting": {
+			a:    "time --format=\"yyyy\"|log",
+			b:    "time --format='yyyy' | log",
+			want: true,
+		},
+		"ArgumentOrder": {
+			a:    "http --a=1 --b=2 | log",
+			b:    "http --b=2 --a=1 | log",
+			want: true,
+		},
+		"DifferentArgumentValue": {
+			a:    "http --a=1 | log",
+			b:    "http --a=2 | log",
+			want: false,
+		},
+		"GreaterThanQuoting": {
+			a:    "time | filter --expression=payload>5 | log",
+			b:    "time | filter --expression='payload>5' | log",
+			want: true,
+		},
+		"DifferentAppOrder": {
+			a:    "a | b | c",
+			b:    "a | c | b",
+			want: false,
+		},
+		"DifferentLabel": {
+			a:    "time | l1: log",
+			b:    "time | l2: log",
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := EquivalentStreams(tc.a, tc.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("EquivalentStreams('%s', '%s'): expected %v, got %v", tc.a, tc.b, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestQuoteArgValue(t *testing.T) {
+	cases := map[string]string{
+		"plain":           "plain",
+		"a,b=c":           "a,b=c",
+		"[my-secret]":     "[my-secret]",
+		"payload != null": "'payload != null'",
+		"it's":            "'it''s'",
+		`say "hi"`:        `'say "hi"'`,
+		"a|b":             "'a|b'",
+		"a>b":             "'a>b'",
+		"":                "''",
+	}
+
+	for value, want := range cases {
+		if got := QuoteArgValue(value); got != want {
+			t.Errorf("QuoteArgValue('%s'): expected %s, got %s", value, want, got)
+		}
+	}
+}
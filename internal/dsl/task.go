@@ -0,0 +1,341 @@
+package dsl
+
+import (
+	"strings"
+)
+
+// Special transition targets
+const (
+	TransitionEnd  = "$END"
+	TransitionFail = "$FAIL"
+)
+
+// TaskNode is a parsed task definition. A composed task is a flow of task
+// apps and splits, i.e. "a 'FAILED'->b && <c || d> && e"
+type TaskNode struct {
+	Flow *FlowNode
+}
+
+// FlowNode is a sequence of elements joined by "&&"
+type FlowNode struct {
+	Elements []TaskElementNode
+}
+
+// TaskElementNode is either a *TaskAppNode or a *SplitNode
+type TaskElementNode interface {
+	String() string
+	taskElement()
+}
+
+// SplitNode runs its flows in parallel, i.e. "<a || b && c>"
+type SplitNode struct {
+	Flows []*FlowNode
+}
+
+// TaskAppNode is a task app within a task definition, i.e.
+// "ts: timestamp --format=yyyy 'FAILED'->cleanup"
+type TaskAppNode struct {
+	Label       string
+	Name        string
+	Arguments   []*ArgumentNode
+	Transitions []*TransitionNode
+}
+
+// TransitionNode continues with the target, if the task app exits with the
+// exit status. The exit status "*" matches every status.
+type TransitionNode struct {
+	ExitStatus string
+	Target     *TaskAppNode
+}
+
+func (n *SplitNode) taskElement()   {}
+func (n *TaskAppNode) taskElement() {}
+
+// LabelOrName returns the name, the task app is referenced with in the
+// composed task
+func (n *TaskAppNode) LabelOrName() string {
+	if n.Label != "" {
+		return n.Label
+	}
+	return n.Name
+}
+
+// IsSpecialTarget reports whether the task app is one of the transition
+// targets $END or $FAIL
+func (n *TaskAppNode) IsSpecialTarget() bool {
+	return n.Name == TransitionEnd || n.Name == TransitionFail
+}
+
+// ParseTask parses a task definition, which is either a single task app or
+// a composed task
+func ParseTask(definition string) (*TaskNode, error) {
+	s := newScanner(definition)
+
+	flow, err := s.flow()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.peek("||") {
+		return nil, s.errorf("'||' is only allowed within a split '<...>'")
+	}
+
+	if !s.eof() {
+		return nil, s.errorf("unexpected '%s'", string(s.input[s.pos]))
+	}
+
+	return &TaskNode{Flow: flow}, nil
+}
+
+func (s *scanner) flow() (*FlowNode, error) {
+	flow := &FlowNode{}
+	for {
+		element, err := s.taskElement()
+		if err != nil {
+			return nil, err
+		}
+		flow.Elements = append(flow.Elements, element)
+
+		if !s.accept("&&") {
+			return flow, nil
+		}
+	}
+}
+
+func (s *scanner) taskElement() (TaskElementNode, error) {
+	if !s.accept("<") {
+		return s.taskApp(true)
+	}
+
+	split := &SplitNode{}
+	for {
+		flow, err := s.flow()
+		if err != nil {
+			return nil, err
+		}
+		split.Flows = append(split.Flows, flow)
+
+		if !s.accept("||") {
+			break
+		}
+	}
+
+	if err := s.expect(">"); err != nil {
+		return nil, err
+	}
+	return split, nil
+}
+
+func (s *scanner) taskApp(withTransitions bool) (*TaskAppNode, error) {
+	app := &TaskAppNode{}
+
+	if s.peekIdentifierFollowedBy(":") {
+		label, _ := s.identifier()
+		app.Label = label
+		s.accept(":")
+	}
+
+	name, err := s.identifier()
+	if err != nil {
+		return nil, err
+	}
+	app.Name = name
+
+	if app.IsSpecialTarget() && withTransitions {
+		return nil, s.errorf("'%s' is only allowed as transition target", name)
+	}
+
+	arguments, err := s.arguments()
+	if err != nil {
+		return nil, err
+	}
+	app.Arguments = arguments
+
+	for withTransitions {
+		transition, err := s.transition()
+		if err != nil {
+			return nil, err
+		}
+		if transition == nil {
+			break
+		}
+		app.Transitions = append(app.Transitions, transition)
+	}
+
+	return app, nil
+}
+
+// transition reads "<exitStatus>-><target>" and returns nil, if the next
+// tokens are no transition
+func (s *scanner) transition() (*TransitionNode, error) {
+	var exitStatus string
+	var err error
+
+	switch {
+	case s.peek("'") || s.peek("\""):
+		exitStatus, err = s.quoted()
+	case s.accept("*"):
+		exitStatus = "*"
+	case s.peekIdentifierFollowedBy("->"):
+		exitStatus, err = s.identifier()
+	default:
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.expect("->"); err != nil {
+		return nil, err
+	}
+
+	target, err := s.taskApp(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransitionNode{ExitStatus: exitStatus, Target: target}, nil
+}
+
+func (n *TaskNode) String() string {
+	return n.Flow.String()
+}
+
+func (n *FlowNode) String() string {
+	elements := make([]string, 0, len(n.Elements))
+	for _, element := range n.Elements {
+		elements = append(elements, element.String())
+	}
+	return strings.Join(elements, " && ")
+}
+
+func (n *SplitNode) String() string {
+	flows := make([]string, 0, len(n.Flows))
+	for _, flow := range n.Flows {
+		flows = append(flows, flow.String())
+	}
+	return "<" + strings.Join(flows, " || ") + ">"
+}
+
+func (n *TaskAppNode) String() string {
+	var builder strings.Builder
+	if n.Label != "" {
+		builder.WriteString(n.Label + ": ")
+	}
+	builder.WriteString(n.Name)
+	renderArguments(&builder, n.Arguments)
+
+	for _, transition := range n.Transitions {
+		builder.WriteString(" " + transition.String())
+	}
+	return builder.String()
+}
+
+func (n *TransitionNode) String() string {
+	return "'" + strings.ReplaceAll(n.ExitStatus, "'", "''") + "'->" + n.Target.String()
+}
+
+// Composed reports whether the definition is a composed task, rather than a
+// single task app
+func (n *TaskNode) Composed() bool {
+	if len(n.Flow.Elements) != 1 {
+		return true
+	}
+	app, ok := n.Flow.Elements[0].(*TaskAppNode)
+	return !ok || len(app.Transitions) > 0
+}
+
+// Apps returns all task apps of the definition in the order they appear,
+// including transition targets but excluding $END and $FAIL
+func (n *TaskNode) Apps() []*TaskAppNode {
+	var apps []*TaskAppNode
+	n.Flow.walk(func(app *TaskAppNode) {
+		if !app.IsSpecialTarget() {
+			apps = append(apps, app)
+		}
+	})
+	return apps
+}
+
+// AppNames returns the distinct names of the registered task apps used by
+// the definition
+func (n *TaskNode) AppNames() []string {
+	var names []string
+	for _, app := range n.Apps() {
+		names = appendDistinct(names, app.Name)
+	}
+	return names
+}
+
+func (n *FlowNode) walk(visit func(app *TaskAppNode)) {
+	for _, element := range n.Elements {
+		switch e := element.(type) {
+		case *TaskAppNode:
+			e.walk(visit)
+		case *SplitNode:
+			for _, flow := range e.Flows {
+				flow.walk(visit)
+			}
+		}
+	}
+}
+
+func (n *TaskAppNode) walk(visit func(app *TaskAppNode)) {
+	visit(n)
+	for _, transition := range n.Transitions {
+		transition.Target.walk(visit)
+	}
+}
+
+// Canonical returns the DSL with all app arguments sorted by name, so that
+// equivalent definitions render equally
+func (n *TaskNode) Canonical() string {
+	return n.Flow.canonical().String()
+}
+
+func (n *FlowNode) canonical() *FlowNode {
+	flow := &FlowNode{}
+	for _, element := range n.Elements {
+		switch e := element.(type) {
+		case *TaskAppNode:
+			flow.Elements = append(flow.Elements, e.canonical())
+		case *SplitNode:
+			split := &SplitNode{}
+			for _, f := range e.Flows {
+				split.Flows = append(split.Flows, f.canonical())
+			}
+			flow.Elements = append(flow.Elements, split)
+		}
+	}
+	return flow
+}
+
+func (n *TaskAppNode) canonical() *TaskAppNode {
+	app := *n
+	app.Arguments = sortArguments(n.Arguments)
+	app.Transitions = nil
+	for _, transition := range n.Transitions {
+		app.Transitions = append(app.Transitions, &TransitionNode{
+			ExitStatus: transition.ExitStatus,
+			Target:     transition.Target.canonical(),
+		})
+	}
+	return &app
+}
+
+// EquivalentTasks reports whether both task definitions describe the same
+// task, regardless of whitespace, quoting and argument order
+func EquivalentTasks(a string, b string) (bool, error) {
+	taskA, err := ParseTask(a)
+	if err != nil {
+		return false, err
+	}
+
+	taskB, err := ParseTask(b)
+	if err != nil {
+		return false, err
+	}
+
+	return taskA.Canonical() == taskB.Canonical(), nil
+}
@@ -0,0 +1,305 @@
+package dsl
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func app(name string) *TaskAppNode {
+	return &TaskAppNode{Name: name}
+}
+
+func flow(elements ...TaskElementNode) *FlowNode {
+	return &FlowNode{Elements: elements}
+}
+
+func TestParseTask(t *testing.T) {
+	cases := map[string]struct {
+		definition string
+		want       *TaskNode
+		rendered   string
+		composed   bool
+		appNames   []string
+	}{
+		"SingleApp": {
+			definition: "timestamp",
+			want:       &TaskNode{Flow: flow(app("timestamp"))},
+			rendered:   "timestamp",
+			composed:   false,
+			appNames:   []string{"timestamp"},
+		},
+		"SingleAppWithArguments": {
+			definition: "timestamp --format=yyyy --spring.cloud.task.closecontext-enabled=true",
+			want: &TaskNode{Flow: flow(&TaskAppNode{
+				Name:      "timestamp",
+				Arguments: []*ArgumentNode{{Name: "format", Value: "yyyy"}, {Name: "spring.cloud.task.closecontext-enabled", Value: "true"}},
+			})},
+			rendered: "timestamp --format=yyyy --spring.cloud.task.closecontext-enabled=true",
+			composed: false,
+			appNames: []string{"timestamp"},
+		},
+		"Sequence": {
+			definition: "task1 && task2 && task3",
+			want:       &TaskNode{Flow: flow(app("task1"), app("task2"), app("task3"))},
+			rendered:   "task1 && task2 && task3",
+			composed:   true,
+			appNames:   []string{"task1", "task2", "task3"},
+		},
+		"Labels": {
+			definition: "foo: timestamp && bar: timestamp --format=yyyy",
+			want: &TaskNode{Flow: flow(
+				&TaskAppNode{Label: "foo", Name: "timestamp"},
+				&TaskAppNode{Label: "bar", Name: "timestamp", Arguments: []*ArgumentNode{{Name: "format", Value: "yyyy"}}},
+			)},
+			rendered: "foo: timestamp && bar: timestamp --format=yyyy",
+			composed: true,
+			appNames: []string{"timestamp"},
+		},
+		"Split": {
+			definition: "<task1 || task2 || task3>",
+			want: &TaskNode{Flow: flow(&SplitNode{Flows: []*FlowNode{
+				flow(app("task1")), flow(app("task2")), flow(app("task3")),
+			}})},
+			rendered: "<task1 || task2 || task3>",
+			composed: true,
+			appNames: []string{"task1", "task2", "task3"},
+		},
+		"SplitFollowedBySequence": {
+			definition: "<task1 || task2> && task3",
+			want: &TaskNode{Flow: flow(
+				&SplitNode{Flows: []*FlowNode{flow(app("task1")), flow(app("task2"))}},
+				app("task3"),
+			)},
+			rendered: "<task1 || task2> && task3",
+			composed: true,
+			appNames: []string{"task1", "task2", "task3"},
+		},
+		"SplitWithSequences": {
+			definition: "<task1 && task2 || task3 && task4>",
+			want: &TaskNode{Flow: flow(&SplitNode{Flows: []*FlowNode{
+				flow(app("task1"), app("task2")), flow(app("task3"), app("task4")),
+			}})},
+			rendered: "<task1 && task2 || task3 && task4>",
+			composed: true,
+			appNames: []string{"task1", "task2", "task3", "task4"},
+		},
+		"NestedSplit": {
+			definition: "a && <b || <c || d> && e> && f",
+			want: &TaskNode{Flow: flow(
+				app("a"),
+				&SplitNode{Flows: []*FlowNode{
+					flow(app("b")),
+					flow(&SplitNode{Flows: []*FlowNode{flow(app("c")), flow(app("d"))}}, app("e")),
+				}},
+				app("f"),
+			)},
+			rendered: "a && <b || <c || d> && e> && f",
+			composed: true,
+			appNames: []string{"a", "b", "c", "d", "e", "f"},
+		},
+		"SplitWithArguments": {
+			definition: "<a --x=1 || b --y='a b'>",
+			want: &TaskNode{Flow: flow(&SplitNode{Flows: []*FlowNode{
+				flow(&TaskAppNode{Name: "a", Arguments: []*ArgumentNode{{Name: "x", Value: "1"}}}),
+				flow(&TaskAppNode{Name: "b", Arguments: []*ArgumentNode{{Name: "y", Value: "a b"}}}),
+			}})},
+			rendered: "<a --x=1 || b --y='a b'>",
+			composed: true,
+			appNames: []string{"a", "b"},
+		},
+		"Transitions": {
+			definition: "task1 'FAILED' -> task2 'COMPLETED' -> task3",
+			want: &TaskNode{Flow: flow(&TaskAppNode{
+				Name: "task1",
+				Transitions: []*TransitionNode{
+					{ExitStatus: "FAILED", Target: app("task2")},
+					{ExitStatus: "COMPLETED", Target: app("task3")},
+				},
+			})},
+			rendered: "task1 'FAILED'->task2 'COMPLETED'->task3",
+			composed: true,
+			appNames: []string{"task1", "task2", "task3"},
+		},
+		"TransitionFollowedBySequence": {
+			definition: "task1 'FAILED'->task2 && task3",
+			want: &TaskNode{Flow: flow(
+				&TaskAppNode{Name: "task1", Transitions: []*TransitionNode{{ExitStatus: "FAILED", Target: app("task2")}}},
+				app("task3"),
+			)},
+			rendered: "task1 'FAILED'->task2 && task3",
+			composed: true,
+			appNames: []string{"task1", "task2", "task3"},
+		},
+		"WildcardTransition": {
+			definition: "task1 '*' -> task2 && task3",
+			want: &TaskNode{Flow: flow(
+				&TaskAppNode{Name: "task1", Transitions: []*TransitionNode{{ExitStatus: "*", Target: app("task2")}}},
+				app("task3"),
+			)},
+			rendered: "task1 '*'->task2 && task3",
+			composed: true,
+			appNames: []string{"task1", "task2", "task3"},
+		},
+		"UnquotedTransitions": {
+			definition: "task1 FAILED->task2 * -> task3",
+			want: &TaskNode{Flow: flow(&TaskAppNode{
+				Name: "task1",
+				Transitions: []*TransitionNode{
+					{ExitStatus: "FAILED", Target: app("task2")},
+					{ExitStatus: "*", Target: app("task3")},
+				},
+			})},
+			rendered: "task1 'FAILED'->task2 '*'->task3",
+			composed: true,
+			appNames: []string{"task1", "task2", "task3"},
+		},
+		"SpecialTransitionTargets": {
+			definition: "task1 'FAILED'->$FAIL 'COMPLETED'->$END",
+			want: &TaskNode{Flow: flow(&TaskAppNode{
+				Name: "task1",
+				Transitions: []*TransitionNode{
+					{ExitStatus: "FAILED", Target: app("$FAIL")},
+					{ExitStatus: "COMPLETED", Target: app("$END")},
+				},
+			})},
+			rendered: "task1 'FAILED'->$FAIL 'COMPLETED'->$END",
+			composed: true,
+			appNames: []string{"task1"},
+		},
+		"TransitionTargetWithLabelAndArguments": {
+			definition: "a 'FAILED'->cleanup: b --force=true && c",
+			want: &TaskNode{Flow: flow(
+				&TaskAppNode{Name: "a", Transitions: []*TransitionNode{{
+					ExitStatus: "FAILED",
+					Target:     &TaskAppNode{Label: "cleanup", Name: "b", Arguments: []*ArgumentNode{{Name: "force", Value: "true"}}},
+				}}},
+				app("c"),
+			)},
+			rendered: "a 'FAILED'->cleanup: b --force=true && c",
+			composed: true,
+			appNames: []string{"a", "b", "c"},
+		},
+		"TransitionsInSplit": {
+			definition: "<a 'FAILED'->b || c> && d",
+			want: &TaskNode{Flow: flow(
+				&SplitNode{Flows: []*FlowNode{
+					flow(&TaskAppNode{Name: "a", Transitions: []*TransitionNode{{ExitStatus: "FAILED", Target: app("b")}}}),
+					flow(app("c")),
+				}},
+				app("d"),
+			)},
+			rendered: "<a 'FAILED'->b || c> && d",
+			composed: true,
+			appNames: []string{"a", "b", "c", "d"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseTask(tc.definition)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseTask(...): -want, +got:\n%s", diff)
+			}
+
+			if rendered := got.String(); rendered != tc.rendered {
+				t.Errorf("String(): expected '%s', got '%s'", tc.rendered, rendered)
+			}
+
+			// Rendering must round-trip to the same AST
+			reparsed, err := ParseTask(got.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(got, reparsed); diff != "" {
+				t.Errorf("round-trip: -want, +got:\n%s", diff)
+			}
+
+			if got.Composed() != tc.composed {
+				t.Errorf("Composed(): expected %v, got %v", tc.composed, got.Composed())
+			}
+
+			if diff := cmp.Diff(tc.appNames, got.AppNames()); diff != "" {
+				t.Errorf("AppNames(): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseTaskErrors(t *testing.T) {
+	cases := map[string]string{
+		"Empty":                  "",
+		"TrailingAnd":            "a &&",
+		"LeadingAnd":             "&& a",
+		"ParallelOutsideSplit":   "a || b",
+		"UnclosedSplit":          "<a || b",
+		"EmptySplit":             "<>",
+		"TransitionWithoutArrow": "a 'FAILED' b",
+		"TransitionWithoutApp":   "a 'FAILED'->",
+		"EndAsElement":           "$END && a",
+		"UnterminatedQuote":      "a 'FAILED->b",
+		"ArgumentWithoutValue":   "a --format",
+		"MissingAnd":             "a b",
+	}
+
+	for name, definition := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseTask(definition)
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("expected ParseError for '%s', got %v", definition, err)
+			}
+		})
+	}
+}
+
+func TestEquivalentTasks(t *testing.T) {
+	cases := map[string]struct {
+		a    string
+		b    string
+		want bool
+	}{
+		"Whitespace": {
+			a:    "a&&<b||c>&&d",
+			b:    "a && <b || c> && d",
+			want: true,
+		},
+		"TransitionQuoting": {
+			a:    "a FAILED->b",
+			b:    "a 'FAILED' -> b",
+			want: true,
+		},
+		"ArgumentOrder": {
+			a:    "a --x=1 --y=2 && b",
+			b:    "a --y=2 --x=1 && b",
+			want: true,
+		},
+		"SplitOrder": {
+			a:    "<a || b>",
+			b:    "<b || a>",
+			want: false,
+		},
+		"DifferentTransition": {
+			a:    "a 'FAILED'->b",
+			b:    "a 'COMPLETED'->b",
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := EquivalentTasks(tc.a, tc.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("EquivalentTasks('%s', '%s'): expected %v, got %v", tc.a, tc.b, tc.want, got)
+			}
+		})
+	}
+}